@@ -0,0 +1,22 @@
+package main
+
+import "os"
+
+// isTerminal reports whether f is connected to a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorEnabled resolves --color always, never or auto. In auto mode output
+// is colored when it goes to a terminal and NO_COLOR is not set to a
+// non-empty value.
+func colorEnabled(mode string, terminal bool) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	return terminal && os.Getenv("NO_COLOR") == ""
+}
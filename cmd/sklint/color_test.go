@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		mode     string
+		terminal bool
+		noColor  string
+		want     bool
+	}{
+		{"always", false, "1", true},
+		{"never", true, "", false},
+		{"auto", true, "", true},
+		{"auto", false, "", false},
+		{"auto", true, "1", false},
+	}
+	for _, tt := range tests {
+		t.Setenv("NO_COLOR", tt.noColor)
+		if got := colorEnabled(tt.mode, tt.terminal); got != tt.want {
+			t.Fatalf("colorEnabled(%q, %v) with NO_COLOR=%q = %v, want %v", tt.mode, tt.terminal, tt.noColor, got, tt.want)
+		}
+	}
+}
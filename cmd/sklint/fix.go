@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sven1103-agent/sklint/internal/diff"
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// fixSkill rewrites SKILL.md with every fixable finding repaired. With dryRun
+// the changes are written to out as a unified diff instead, and a note about
+// applied fixes goes to log otherwise.
+func fixSkill(path string, opts validator.Options, dryRun bool, out, log io.Writer) error {
+	fixed, err := validator.FixSkill(path, opts)
+	if err != nil || !fixed.Changed() {
+		return err
+	}
+
+	name := fixed.File
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, fixed.File); err == nil {
+			name = rel
+		}
+	}
+	name = filepath.ToSlash(name)
+
+	if dryRun {
+		_, err := io.WriteString(out, diff.Unified("a/"+name, "b/"+name, string(fixed.Original), string(fixed.Fixed)))
+		return err
+	}
+
+	info, err := os.Stat(fixed.File)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(fixed.File, fixed.Fixed, info.Mode().Perm()); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(log, "Fixed %d findings in %s\n", len(fixed.Fixes), name)
+	return err
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/sven1103-agent/sklint/internal/config"
+	"github.com/sven1103-agent/sklint/internal/lsp"
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// runLSP serves the language server on stdin and stdout. Configuration is
+// resolved again for every validation so edits to .sklint.yaml apply without
+// restarting the editor.
+func runLSP(args []string) {
+	flags := flag.NewFlagSet("sklint lsp", flag.ExitOnError)
+	configPath := flags.String("config", "", "Use this configuration file instead of discovering "+config.FileName)
+	_ = flags.Parse(args)
+
+	options := func(dir string) (validator.Options, error) {
+		cfg, err := newConfigResolver(*configPath).forPath(dir)
+		if err != nil {
+			return validator.Options{}, err
+		}
+		return applyConfig(validator.Options{CheckRefsExist: true}, cfg, nil)
+	}
+	if err := lsp.NewServer(os.Stdin, os.Stdout, options).Run(); err != nil {
+		exitWithError(err.Error())
+	}
+}
@@ -1,77 +1,278 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 
+	"github.com/sven1103-agent/sklint/internal/baseline"
+	"github.com/sven1103-agent/sklint/internal/config"
 	"github.com/sven1103-agent/sklint/internal/report"
 	"github.com/sven1103-agent/sklint/pkg/validator"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "lsp":
+			runLSP(os.Args[2:])
+			return
+		case "pack":
+			os.Exit(runPack(os.Args[2:], os.Stdout, os.Stderr))
+		case "rules":
+			os.Exit(runRules(os.Args[2:], os.Stdout, os.Stderr))
+		case "explain":
+			os.Exit(runExplain(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
 	var (
 		format      string
+		color       string
 		strict      bool
 		noWarn      bool
 		output      string
 		followLinks bool
+		configPath  string
+		fix         bool
+		dryRun      bool
+		watch       bool
+		portable    bool
+		baseFile    string
+		writeBase   string
 	)
 
-	flag.StringVar(&format, "format", "text", "Output format: text or json")
+	flag.StringVar(&format, "format", "", "Output format: pretty, text, json, sarif, github, junit, gitlab, checkstyle, markdown or html (default pretty on a terminal, text otherwise)")
+	flag.StringVar(&color, "color", "auto", "Color pretty output: always, never or auto")
 	flag.BoolVar(&strict, "strict", false, "Treat warnings as errors")
 	flag.BoolVar(&noWarn, "no-warn", false, "Suppress warnings")
 	flag.StringVar(&output, "output", "", "Write report to file")
 	flag.BoolVar(&followLinks, "follow-symlinks", false, "Follow symlinks")
+	flag.BoolVar(&portable, "portability", false, "Warn about YAML constructs that agent hosts parse inconsistently")
+	flag.StringVar(&configPath, "config", "", "Use this configuration file instead of discovering "+config.FileName)
+	flag.BoolVar(&fix, "fix", false, "Rewrite SKILL.md to repair fixable findings")
+	flag.BoolVar(&dryRun, "dry-run", false, "With --fix, print a unified diff instead of writing files; the report is only written with --output")
+	flag.BoolVar(&watch, "watch", false, "Keep running and re-validate skills when their files change")
+	flag.StringVar(&baseFile, "baseline", "", "Report only findings that are not recorded in this baseline file")
+	flag.StringVar(&writeBase, "write-baseline", "", "Record the current findings in this baseline file")
 	flag.Parse()
 
-	if flag.NArg() != 1 {
-		exitWithError("Usage: sklint <path>")
+	if flag.NArg() < 1 {
+		exitWithError("Usage: sklint <path> [<path>...]")
+	}
+	if dryRun && !fix {
+		exitWithError("--dry-run requires --fix")
+	}
+	if watch && (fix || output != "" || writeBase != "") {
+		exitWithError("--watch cannot be combined with --fix, --output or --write-baseline")
+	}
+	if baseFile != "" && writeBase != "" {
+		exitWithError("--baseline cannot be combined with --write-baseline")
+	}
+	var known *baseline.Baseline
+	if baseFile != "" {
+		b, err := baseline.Load(baseFile)
+		if err != nil {
+			exitWithError(err.Error())
+		}
+		known = b
+	}
+
+	set := explicitFlags()
+	configs := newConfigResolver(configPath)
+	cfg, err := configs.forPath(flag.Arg(0))
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	if cfg != nil && cfg.Format != "" && !set["format"] {
+		format = cfg.Format
+	}
+	terminal := output == "" && isTerminal(os.Stdout)
+	if format == "" {
+		format = "text"
+		if terminal {
+			format = "pretty"
+		}
 	}
-	if format != "text" && format != "json" {
+	switch format {
+	case "pretty", "text", "json", "sarif", "github", "junit", "gitlab", "checkstyle", "markdown", "html":
+	default:
 		exitWithError(fmt.Sprintf("Unsupported format: %s", format))
 	}
+	if color != "always" && color != "never" && color != "auto" {
+		exitWithError(fmt.Sprintf("Unsupported color mode: %s", color))
+	}
+	colored := colorEnabled(color, terminal)
 
-	path := flag.Arg(0)
-	opts := validator.Options{
+	baseOpts := validator.Options{
 		Strict:         strict,
 		NoWarn:         noWarn,
 		FollowSymlinks: followLinks,
 		CheckRefsExist: true,
+		Portability:    portable,
+	}
+
+	// matched holds the baseline entries each skill matched, by result path.
+	matched := make(map[string][]baseline.Entry)
+	check := func(skill string) (validator.Result, error) {
+		cfg, err := configs.forPath(skill)
+		if err != nil {
+			return validator.Result{}, err
+		}
+		opts, err := applyConfig(baseOpts, cfg, set)
+		if err != nil {
+			return validator.Result{}, err
+		}
+		if fix {
+			if err := fixSkill(skill, opts, dryRun, os.Stdout, os.Stderr); err != nil {
+				return validator.Result{}, err
+			}
+		}
+		result, err := validator.ValidateSkill(skill, opts)
+		if err != nil || known == nil {
+			return result, err
+		}
+		result, entries, err := known.Apply(result, opts.Strict)
+		matched[result.Path] = entries
+		return result, err
+	}
+
+	if watch {
+		err := newWatcher(flag.Args(), configs, check).run(os.Stdout, func(results []validator.Result) ([]byte, error) {
+			out, err := render(format, colored, results)
+			if err != nil || known == nil {
+				return out, err
+			}
+			b := bytes.NewBuffer(out)
+			writeUnmatched(b, known, matched, results, baseFile)
+			return b.Bytes(), nil
+		})
+		exitWithError(err.Error())
 	}
 
-	result, err := validator.ValidateSkill(path, opts)
+	skills, err := validator.DiscoverSkills(flag.Args())
 	if err != nil {
 		exitWithError(err.Error())
 	}
+	results := make([]validator.Result, 0, len(skills))
+	for _, skill := range skills {
+		result, err := check(skill)
+		if err != nil {
+			exitWithError(err.Error())
+		}
+		results = append(results, result)
+	}
 
-	var outputBytes []byte
-	if format == "json" {
-		outputBytes, err = report.RenderJSON(result)
+	if writeBase != "" {
+		b, err := baseline.New(filepath.Dir(writeBase), results)
 		if err != nil {
 			exitWithError(err.Error())
 		}
-		outputBytes = append(outputBytes, '\n')
-	} else {
-		outputBytes = []byte(report.RenderText(result))
+		if err := b.Write(writeBase); err != nil {
+			exitWithError(err.Error())
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d findings to %s\n", len(b.Findings), writeBase)
+		os.Exit(0)
+	}
+	if known != nil {
+		writeUnmatched(os.Stderr, known, matched, results, baseFile)
+	}
+
+	outputBytes, err := render(format, colored, results)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	if format == "github" {
+		if err := report.WriteGitHubSummary(results); err != nil {
+			exitWithError(err.Error())
+		}
 	}
 
 	if output != "" {
 		if err := os.WriteFile(output, outputBytes, 0o644); err != nil {
 			exitWithError(err.Error())
 		}
-	} else {
+	} else if !dryRun {
+		// With --dry-run, stdout carries the diff and must stay a valid patch.
 		if _, err := os.Stdout.Write(outputBytes); err != nil {
 			exitWithError(err.Error())
 		}
 	}
 
-	if result.Valid {
+	if validator.Summarize(results).Invalid == 0 {
 		os.Exit(0)
 	}
 	os.Exit(1)
 }
 
+// documentRenderers render all results as one document, whatever the
+// number of skills.
+var documentRenderers = map[string]func([]validator.Result) ([]byte, error){
+	"sarif":      report.RenderSARIF,
+	"junit":      report.RenderJUnit,
+	"gitlab":     report.RenderGitLab,
+	"checkstyle": report.RenderCheckstyle,
+	"html":       report.RenderHTML,
+}
+
+// render keeps the single-skill report layout when only one skill was
+// validated and switches to the grouped layout otherwise.
+func render(format string, color bool, results []validator.Result) ([]byte, error) {
+	if renderer, ok := documentRenderers[format]; ok {
+		out, err := renderer(results)
+		if err != nil {
+			return nil, err
+		}
+		return append(out, '\n'), nil
+	}
+	if format == "github" {
+		return report.RenderGitHub(results), nil
+	}
+	if format == "markdown" {
+		return []byte(report.RenderMarkdown(results)), nil
+	}
+	if format == "pretty" {
+		return []byte(report.RenderPretty(results, color)), nil
+	}
+	if format == "json" {
+		var out []byte
+		var err error
+		if len(results) == 1 {
+			out, err = report.RenderJSON(results[0])
+		} else {
+			out, err = report.RenderJSONReport(results)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return append(out, '\n'), nil
+	}
+	if len(results) == 1 {
+		return []byte(report.RenderText(results[0])), nil
+	}
+	return []byte(report.RenderTextReport(results)), nil
+}
+
+// writeUnmatched lists the baseline entries that matched no finding of
+// results, including those of skills that no longer exist.
+func writeUnmatched(w io.Writer, known *baseline.Baseline, matched map[string][]baseline.Entry, results []validator.Result, baseFile string) {
+	all := make([]baseline.Entry, 0)
+	for _, result := range results {
+		all = append(all, matched[result.Path]...)
+	}
+	unmatched := known.Unmatched(all)
+	if len(unmatched) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%d baseline entries no longer match a finding; run --write-baseline %s to prune them:\n", len(unmatched), baseFile)
+	for _, entry := range unmatched {
+		fmt.Fprintf(w, "- %s %s (%s)\n", entry.Code, entry.File, entry.Fingerprint)
+	}
+}
+
 func exitWithError(message string) {
 	_, _ = fmt.Fprintln(os.Stderr, message)
 	os.Exit(2)
@@ -2,8 +2,10 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -34,3 +36,70 @@ func TestExitWithError(t *testing.T) {
 		t.Fatalf("expected stderr to contain message, got %q", stderr.String())
 	}
 }
+
+// runMain runs main with args in a subprocess and returns its stdout and
+// exit code.
+func runMain(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=TestMainHelper")
+	cmd.Env = append(os.Environ(), "SKLINT_MAIN_HELPER=1", "SKLINT_MAIN_ARGS="+strings.Join(args, "\x1f"))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	code := 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		code = exitErr.ExitCode()
+	} else if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return stdout.String(), code
+}
+
+func TestMainHelper(t *testing.T) {
+	if os.Getenv("SKLINT_MAIN_HELPER") != "1" {
+		t.Skip("only runs as a subprocess of runMain")
+	}
+	os.Args = append([]string{"sklint"}, strings.Split(os.Getenv("SKLINT_MAIN_ARGS"), "\x1f")...)
+	main()
+}
+
+func TestFixDryRunKeepsStdoutADiff(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: other-name\ndescription: Fixable.\n---\nBody\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(t.TempDir(), "report.json")
+
+	stdout, code := runMain(t, "--fix", "--dry-run", "--format", "json", "--output", output, dir)
+	if code != 1 {
+		t.Fatalf("expected exit 1 for the unfixed skill, got %d", code)
+	}
+	if !strings.HasPrefix(stdout, "--- a/") || strings.Contains(stdout, "{") {
+		t.Fatalf("expected only a diff on stdout, got %q", stdout)
+	}
+	report, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(report, &decoded); err != nil {
+		t.Fatalf("expected a valid json report, got %v: %s", err, report)
+	}
+
+	stdout, _ = runMain(t, "--fix", "--dry-run", "--format", "json", dir)
+	if !strings.HasPrefix(stdout, "--- a/") || strings.Contains(stdout, "{") {
+		t.Fatalf("expected only a diff on stdout, got %q", stdout)
+	}
+	unchanged, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(unchanged) != content {
+		t.Fatalf("dry run modified SKILL.md: %q", unchanged)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/sven1103-agent/sklint/internal/config"
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// configResolver finds the configuration that applies to a path: the file
+// given with --config, or else the nearest .sklint.yaml above the path.
+// Loaded files are cached so a collection of skills shares one parse; a file
+// is loaded again once its size or modification time changes, so --watch
+// picks up edits.
+type configResolver struct {
+	explicit string
+	cache    map[string]cachedConfig
+}
+
+type cachedConfig struct {
+	cfg   *config.Config
+	state string
+}
+
+func newConfigResolver(explicit string) *configResolver {
+	return &configResolver{explicit: explicit, cache: make(map[string]cachedConfig)}
+}
+
+// file returns the configuration file that applies to path, or "" when there
+// is none.
+func (r *configResolver) file(path string) (string, error) {
+	if r.explicit != "" {
+		return r.explicit, nil
+	}
+	dir := path
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		dir = filepath.Dir(path)
+	}
+	return config.Find(dir)
+}
+
+func (r *configResolver) forPath(path string) (*config.Config, error) {
+	configPath, err := r.file(path)
+	if err != nil || configPath == "" {
+		return nil, err
+	}
+	state := fileState(configPath)
+	if cached, ok := r.cache[configPath]; ok && cached.state == state {
+		return cached.cfg, nil
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	r.cache[configPath] = cachedConfig{cfg: &cfg, state: state}
+	return &cfg, nil
+}
+
+// explicitFlags reports which flags were set on the command line; those take
+// precedence over values from the configuration file.
+func explicitFlags() map[string]bool {
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
+
+func applyConfig(opts validator.Options, cfg *config.Config, set map[string]bool) (validator.Options, error) {
+	if cfg == nil {
+		return opts, nil
+	}
+	if cfg.Strict != nil && !set["strict"] {
+		opts.Strict = *cfg.Strict
+	}
+	if cfg.FollowSymlinks != nil && !set["follow-symlinks"] {
+		opts.FollowSymlinks = *cfg.FollowSymlinks
+	}
+	if cfg.Portability != nil && !set["portability"] {
+		opts.Portability = *cfg.Portability
+	}
+	opts.MaxArchiveSize = cfg.MaxArchiveSize
+	opts.KnownTools = cfg.KnownTools
+	opts.AllowedLicenses = cfg.Licenses.Allow
+	opts.DeniedLicenses = cfg.Licenses.Deny
+	schema, err := cfg.MetadataSchema()
+	if err != nil {
+		return opts, err
+	}
+	opts.MetadataSchema = schema
+	severity, err := cfg.Severity()
+	if err != nil {
+		return opts, err
+	}
+	opts.Severity = severity
+	return opts, nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sven1103-agent/sklint/internal/config"
+	"github.com/sven1103-agent/sklint/internal/pack"
+	"github.com/sven1103-agent/sklint/internal/report"
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// runPack validates a skill and writes it as a zip bundle. The bundle is
+// written to a temporary file and validated again before it is moved into
+// place, so a failed run never leaves a partial or broken archive behind.
+func runPack(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("sklint pack", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "", "Write the bundle to this file (default <skill-name>.zip)")
+	configPath := flags.String("config", "", "Use this configuration file instead of discovering "+config.FileName)
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 {
+		fmt.Fprintln(stderr, "Usage: sklint pack <skill-dir> [-o <file>]")
+		return 2
+	}
+	dir := positional[0]
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		fmt.Fprintf(stderr, "Refusing to pack %s: not a skill directory.\n", dir)
+		return 2
+	}
+
+	cfg, err := newConfigResolver(*configPath).forPath(dir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	opts, err := applyConfig(validator.Options{CheckRefsExist: true}, cfg, nil)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	result, err := validator.ValidateSkill(dir, opts)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if !result.Valid {
+		fmt.Fprint(stderr, report.RenderText(result))
+		fmt.Fprintf(stderr, "Refusing to pack %s: fix the errors above first.\n", dir)
+		return 1
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if *output == "" {
+		*output = filepath.Base(abs) + ".zip"
+	}
+	files, err := pack.Collect(dir, *output)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(*output), ".sklint-pack-*.zip")
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := pack.Zip(tmp, dir, files); err != nil {
+		tmp.Close()
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if err := tmp.Close(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	bundle, err := validator.ValidateArchive(tmp.Name(), opts)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if !bundle.Valid {
+		fmt.Fprint(stderr, report.RenderText(bundle))
+		fmt.Fprintf(stderr, "Refusing to write %s: the bundle does not validate; check %s.\n", *output, pack.IgnoreFileName)
+		return 1
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if err := os.Rename(tmp.Name(), *output); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	fmt.Fprintf(stdout, "Packed %d files from %s into %s\n", len(files), dir, *output)
+	return 0
+}
+
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments, as in "sklint pack ./my-skill -o my-skill.zip".
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePackSkill(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: "+name+"\ndescription: Packed.\n---\n"+content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestRunPack(t *testing.T) {
+	dir := writePackSkill(t, "my-skill", "Body\n")
+	output := filepath.Join(t.TempDir(), "bundle.zip")
+
+	var stdout, stderr bytes.Buffer
+	if code := runPack([]string{dir, "-o", output}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Packed 1 files") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Fatalf("bundle not written: %v", err)
+	}
+}
+
+func TestRunPackRefusesInvalidSkill(t *testing.T) {
+	dir := writePackSkill(t, "Other_Name", "Body\n")
+	output := filepath.Join(t.TempDir(), "bundle.zip")
+
+	var stdout, stderr bytes.Buffer
+	if code := runPack([]string{"-o", output, dir}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "NAME_INVALID_CHARS") || !strings.Contains(stderr.String(), "Refusing to pack") {
+		t.Fatalf("unexpected stderr %q", stderr.String())
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Fatalf("expected no bundle, got %v", err)
+	}
+}
+
+func TestRunPackUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runPack(nil, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit 2, got %d", code)
+	}
+}
+
+func TestRunPackRefusesArchive(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "my-skill.zip")
+	if err := os.WriteFile(archive, []byte("not a directory"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runPack([]string{archive}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "not a skill directory") {
+		t.Fatalf("unexpected stderr %q", stderr.String())
+	}
+}
+
+func TestRunPackRefusesBrokenBundle(t *testing.T) {
+	dir := writePackSkill(t, "my-skill", "Body\n")
+	if err := os.WriteFile(filepath.Join(dir, ".sklintignore"), []byte("SKILL.md\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	output := filepath.Join(outDir, "bundle.zip")
+
+	var stdout, stderr bytes.Buffer
+	if code := runPack([]string{dir, "-o", output}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit 1, got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "SKILL_MD_MISSING") || !strings.Contains(stderr.String(), "Refusing to write") {
+		t.Fatalf("unexpected stderr %q", stderr.String())
+	}
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no files left behind, got %v", entries)
+	}
+}
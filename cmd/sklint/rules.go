@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// runRules lists every code the validator can report.
+func runRules(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("sklint rules", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "text", "Output format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 0 {
+		fmt.Fprintln(stderr, "Usage: sklint rules [--format text|json]")
+		return 2
+	}
+
+	codes := validator.Codes()
+	switch *format {
+	case "text":
+		w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "CODE\tSEVERITY\tCATEGORY\tFIXABLE\tDESCRIPTION")
+		for _, info := range codes {
+			fixable := "no"
+			if info.Fixable {
+				fixable = "yes"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", info.Code, info.Level, info.Category, fixable, info.Description)
+		}
+		w.Flush()
+	case "json":
+		data, err := json.MarshalIndent(codes, "", "  ")
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		fmt.Fprintln(stdout, string(data))
+	default:
+		fmt.Fprintf(stderr, "Unsupported format: %s\n", *format)
+		return 2
+	}
+	return 0
+}
+
+// runExplain prints the long documentation of a code.
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 1 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprintln(stderr, "Usage: sklint explain <CODE>")
+		return 2
+	}
+	info, doc, ok := validator.Explain(args[0])
+	if !ok {
+		fmt.Fprintf(stderr, "Unknown code: %s (run 'sklint rules' to list all codes)\n", args[0])
+		return 2
+	}
+
+	tags := []string{string(info.Level), info.Category}
+	if info.Fixable {
+		tags = append(tags, "fixable with --fix")
+	}
+	fmt.Fprintf(stdout, "%s (%s)\n\n", info.Code, strings.Join(tags, ", "))
+	fmt.Fprintf(stdout, "%s.\n\n%s\n", info.Description, doc.Details)
+	if doc.Bad != "" {
+		fmt.Fprintf(stdout, "\nBad:\n\n%s\n", indent(doc.Bad))
+	}
+	if doc.Good != "" {
+		fmt.Fprintf(stdout, "\nGood:\n\n%s\n", indent(doc.Good))
+	}
+	if doc.Fix != "" {
+		fmt.Fprintf(stdout, "\nHow to fix:\n\n%s\n", doc.Fix)
+	}
+	return 0
+}
+
+func indent(text string) string {
+	return "    " + strings.ReplaceAll(text, "\n", "\n    ")
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func TestRunRules(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runRules(nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != len(validator.Codes())+1 || !strings.HasPrefix(lines[0], "CODE") {
+		t.Fatalf("unexpected table %q", stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "NAME_INVALID_CHARS") {
+		t.Fatalf("expected NAME_INVALID_CHARS in %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := runRules([]string{"--format", "json"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr.String())
+	}
+	var codes []validator.CodeInfo
+	if err := json.Unmarshal(stdout.Bytes(), &codes); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(codes) != len(validator.Codes()) {
+		t.Fatalf("expected %d codes, got %d", len(validator.Codes()), len(codes))
+	}
+
+	if code := runRules([]string{"--format", "xml"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit 2, got %d", code)
+	}
+}
+
+func TestRunExplain(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runExplain([]string{"name_mismatch_directory"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr.String())
+	}
+	for _, want := range []string{"NAME_MISMATCH_DIRECTORY (error, name, fixable with --fix)", "Bad:", "Good:", "How to fix:"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Fatalf("expected %q in %q", want, stdout.String())
+		}
+	}
+
+	if code := runExplain([]string{"NOPE"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "Unknown code: NOPE") {
+		t.Fatalf("unexpected stderr %q", stderr.String())
+	}
+}
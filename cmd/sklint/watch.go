@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// watchInterval is how often --watch polls the skills for changes.
+const watchInterval = 500 * time.Millisecond
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// watchedPaths are the parts of a skill directory whose changes trigger a
+// new validation.
+var watchedPaths = []string{"SKILL.md", "scripts", "references", "assets"}
+
+// watcher re-validates skills whose files or configuration file changed
+// since the last poll. It polls modification times and sizes instead of
+// using platform-specific file system notifications.
+type watcher struct {
+	paths   []string
+	configs *configResolver
+	check   func(skill string) (validator.Result, error)
+	states  map[string]string
+	cache   map[string]validator.Result
+}
+
+func newWatcher(paths []string, configs *configResolver, check func(skill string) (validator.Result, error)) *watcher {
+	return &watcher{
+		paths:   paths,
+		configs: configs,
+		check:   check,
+		states:  make(map[string]string),
+		cache:   make(map[string]validator.Result),
+	}
+}
+
+// poll discovers the skills below the watched paths again and validates
+// those that are new or changed. It returns the results of all skills and
+// the skills that were validated; a poll that validated nothing and removed
+// no skill returns no results.
+func (w *watcher) poll() ([]validator.Result, []string, error) {
+	skills, err := validator.DiscoverSkills(w.paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	changed := make([]string, 0)
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		seen[skill] = true
+		state := skillState(skill)
+		configFile, err := w.configs.file(skill)
+		if err != nil {
+			return nil, nil, err
+		}
+		if configFile != "" {
+			state += "config " + fileState(configFile)
+		}
+		if old, ok := w.states[skill]; ok && old == state {
+			continue
+		}
+		result, err := w.check(skill)
+		if err != nil {
+			return nil, nil, err
+		}
+		w.states[skill] = state
+		w.cache[skill] = result
+		changed = append(changed, skill)
+	}
+	removed := false
+	for skill := range w.states {
+		if !seen[skill] {
+			delete(w.states, skill)
+			delete(w.cache, skill)
+			removed = true
+		}
+	}
+	if len(changed) == 0 && !removed {
+		return nil, nil, nil
+	}
+	results := make([]validator.Result, 0, len(skills))
+	for _, skill := range skills {
+		results = append(results, w.cache[skill])
+	}
+	return results, changed, nil
+}
+
+// run polls until the process is interrupted, clearing the screen and
+// printing the rendered report after every poll that found changes.
+func (w *watcher) run(out io.Writer, render func([]validator.Result) ([]byte, error)) error {
+	for {
+		results, changed, err := w.poll()
+		if err != nil {
+			return err
+		}
+		if results != nil {
+			report, err := render(results)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(out, clearScreen)
+			if _, err := out.Write(report); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "\n[%s] Validated %s. Watching %d skill(s) for changes; press Ctrl+C to stop.\n",
+				time.Now().Format("15:04:05"), describeChanged(changed), len(results))
+		}
+		time.Sleep(watchInterval)
+	}
+}
+
+func describeChanged(changed []string) string {
+	if len(changed) == 0 {
+		return "no changed skills"
+	}
+	names := make([]string, len(changed))
+	for i, skill := range changed {
+		names[i] = filepath.Base(skill)
+	}
+	return strings.Join(names, ", ")
+}
+
+// skillState summarizes the watched files of a skill by path, size, mode and
+// modification time. Archives are summarized by the archive file itself.
+func skillState(skill string) string {
+	var b strings.Builder
+	info, err := os.Stat(skill)
+	if err != nil {
+		return ""
+	}
+	if !info.IsDir() {
+		fmt.Fprintf(&b, "%d %d %v\n", info.Size(), info.ModTime().UnixNano(), info.Mode())
+		return b.String()
+	}
+	for _, name := range watchedPaths {
+		_ = filepath.WalkDir(filepath.Join(skill, name), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			b.WriteString(fileState(path))
+			return nil
+		})
+	}
+	return b.String()
+}
+
+// fileState summarizes a file by path, size, mode and modification time.
+func fileState(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return path + " missing\n"
+	}
+	return fmt.Sprintf("%s %d %d %v\n", path, info.Size(), info.ModTime().UnixNano(), info.Mode())
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"alpha", "beta"} {
+		dir := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Join(dir, "references"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: "+name+"\ndescription: Watched.\n---\nBody\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	validated := make([]string, 0)
+	w := newWatcher([]string{root}, newConfigResolver(""), func(skill string) (validator.Result, error) {
+		validated = append(validated, filepath.Base(skill))
+		return validator.ValidateSkill(skill, validator.Options{})
+	})
+
+	results, changed, err := w.poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || len(changed) != 2 {
+		t.Fatalf("expected both skills on the first poll, got %d results, %v", len(results), changed)
+	}
+
+	results, _, err = w.poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results != nil {
+		t.Fatalf("expected no results without changes, got %#v", results)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "beta", "references", "guide.md"), []byte("# Guide\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results, changed, err = w.poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || len(changed) != 1 || filepath.Base(changed[0]) != "beta" {
+		t.Fatalf("expected only beta to be re-validated, got %v", changed)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "alpha", "notes.txt"), []byte("unwatched\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if results, _, _ := w.poll(); results != nil {
+		t.Fatal("expected files outside the watched paths to be ignored")
+	}
+
+	if err := os.RemoveAll(filepath.Join(root, "beta")); err != nil {
+		t.Fatal(err)
+	}
+	results, changed, err = w.poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || len(changed) != 0 {
+		t.Fatalf("expected the removed skill to be dropped, got %d results, %v", len(results), changed)
+	}
+
+	if want := []string{"alpha", "beta", "beta"}; !reflect.DeepEqual(validated, want) {
+		t.Fatalf("expected validations %v, got %v", want, validated)
+	}
+}
+
+func TestWatcherPollConfigChange(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "alpha")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: alpha\ndescription: Watched.\nowner: me\n---\nBody\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	configFile := filepath.Join(root, ".sklint.yaml")
+	if err := os.WriteFile(configFile, []byte("rules:\n  UNKNOWN_TOP_LEVEL_KEY: warning\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	configs := newConfigResolver("")
+	w := newWatcher([]string{root}, configs, func(skill string) (validator.Result, error) {
+		cfg, err := configs.forPath(skill)
+		if err != nil {
+			return validator.Result{}, err
+		}
+		opts, err := applyConfig(validator.Options{}, cfg, nil)
+		if err != nil {
+			return validator.Result{}, err
+		}
+		return validator.ValidateSkill(skill, opts)
+	})
+	results, _, err := w.poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || len(results[0].Warnings) != 1 {
+		t.Fatalf("expected the unknown key as a warning, got %#v", results)
+	}
+
+	if err := os.WriteFile(configFile, []byte("rules:\n  UNKNOWN_TOP_LEVEL_KEY: off\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results, changed, err := w.poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 1 || len(results[0].Warnings) != 0 {
+		t.Fatalf("expected the edited configuration to apply, got %v, %#v", changed, results)
+	}
+}
@@ -0,0 +1,199 @@
+// Package baseline records the findings of a skill collection so that later
+// runs only report findings that are new since the baseline was written.
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// Version is the format version written to baseline files. Version 2
+// fingerprints ignore numbers in messages.
+const Version = 2
+
+var digitsPattern = regexp.MustCompile(`[0-9]+`)
+
+// Entry identifies a recorded finding. File is slash-separated and relative
+// to the directory of the baseline file, so a baseline can be committed next
+// to the skills it covers. Line numbers are deliberately left out so entries
+// survive edits elsewhere in the file.
+type Entry struct {
+	Code        string `json:"code"`
+	File        string `json:"file"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+type Baseline struct {
+	Version  int     `json:"version"`
+	Findings []Entry `json:"findings"`
+
+	dir string
+}
+
+// Fingerprint returns a stable hash of a finding's code and message. Numbers
+// in the message are left out, since messages such as "already defined on
+// line 3" or "SKILL.md is 612 lines" change whenever lines are added above.
+func Fingerprint(finding validator.Finding) string {
+	message := digitsPattern.ReplaceAllString(finding.Message, "#")
+	sum := sha256.Sum256([]byte(finding.Code + "\x00" + message))
+	return hex.EncodeToString(sum[:8])
+}
+
+// New returns a baseline of the errors and warnings of results, for a
+// baseline file in dir.
+func New(dir string, results []validator.Result) (*Baseline, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	b := &Baseline{Version: Version, Findings: make([]Entry, 0), dir: dir}
+	for _, result := range results {
+		for _, finding := range append(append([]validator.Finding(nil), result.Errors...), result.Warnings...) {
+			entry, err := b.entry(result, finding)
+			if err != nil {
+				return nil, err
+			}
+			b.Findings = append(b.Findings, entry)
+		}
+	}
+	sort.Slice(b.Findings, func(i, j int) bool {
+		x, y := b.Findings[i], b.Findings[j]
+		if x.File != y.File {
+			return x.File < y.File
+		}
+		if x.Code != y.Code {
+			return x.Code < y.Code
+		}
+		return x.Fingerprint < y.Fingerprint
+	})
+	return b, nil
+}
+
+// Load reads a baseline file.
+func Load(file string) (*Baseline, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var b Baseline
+	if err := json.Unmarshal(content, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if b.Version != Version {
+		return nil, fmt.Errorf("%s: unsupported baseline version %d; write the baseline again", file, b.Version)
+	}
+	dir, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return nil, err
+	}
+	b.dir = dir
+	return &b, nil
+}
+
+// Write stores the baseline in file, which should be in the directory the
+// baseline was created for.
+func (b *Baseline) Write(file string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0o644)
+}
+
+// Apply removes the findings of result that are recorded in the baseline and
+// recomputes Valid; strict treats remaining warnings as invalidating, as
+// Options.Strict does. Each entry matches at most one finding. Apply also
+// returns the entries that matched, which Unmatched needs at the end of a
+// run.
+func (b *Baseline) Apply(result validator.Result, strict bool) (validator.Result, []Entry, error) {
+	remaining := b.counts()
+	matched := make([]Entry, 0)
+	filter := func(findings []validator.Finding) ([]validator.Finding, error) {
+		kept := make([]validator.Finding, 0, len(findings))
+		for _, finding := range findings {
+			entry, err := b.entry(result, finding)
+			if err != nil {
+				return nil, err
+			}
+			if remaining[entry] > 0 {
+				remaining[entry]--
+				matched = append(matched, entry)
+				continue
+			}
+			kept = append(kept, finding)
+		}
+		return kept, nil
+	}
+	var err error
+	if result.Errors, err = filter(result.Errors); err != nil {
+		return result, nil, err
+	}
+	if result.Warnings, err = filter(result.Warnings); err != nil {
+		return result, nil, err
+	}
+	result.Valid = len(result.Errors) == 0 && !(strict && len(result.Warnings) > 0)
+	return result, matched, nil
+}
+
+// Unmatched returns the entries that are not among matched, the entries
+// Apply matched over a whole run. Their findings have been fixed, or their
+// skill was removed or moved, so the baseline can be pruned.
+func (b *Baseline) Unmatched(matched []Entry) []Entry {
+	remaining := b.counts()
+	for _, entry := range matched {
+		if remaining[entry] > 0 {
+			remaining[entry]--
+		}
+	}
+	unmatched := make([]Entry, 0)
+	for _, entry := range b.Findings {
+		if remaining[entry] > 0 {
+			remaining[entry]--
+			unmatched = append(unmatched, entry)
+		}
+	}
+	return unmatched
+}
+
+// counts returns how often each entry is recorded.
+func (b *Baseline) counts() map[Entry]int {
+	counts := make(map[Entry]int, len(b.Findings))
+	for _, entry := range b.Findings {
+		counts[entry]++
+	}
+	return counts
+}
+
+func (b *Baseline) entry(result validator.Result, finding validator.Finding) (Entry, error) {
+	file, err := b.rel(result.Path)
+	if err != nil {
+		return Entry{}, err
+	}
+	if finding.File != "" {
+		file = path.Join(file, finding.File)
+	}
+	return Entry{Code: finding.Code, File: file, Fingerprint: Fingerprint(finding)}, nil
+}
+
+// rel returns the slash-separated path of a skill relative to the baseline
+// directory.
+func (b *Baseline) rel(skill string) (string, error) {
+	abs, err := filepath.Abs(skill)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(b.dir, abs)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
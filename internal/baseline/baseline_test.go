@@ -0,0 +1,165 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func writeSkill(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func validate(t *testing.T, dir string) validator.Result {
+	t.Helper()
+	result, err := validator.ValidateSkill(dir, validator.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestBaseline(t *testing.T) {
+	root := t.TempDir()
+	skill := filepath.Join(root, "skills", "legacy")
+	writeSkill(t, skill, "---\nname: legacy\ndescription: Old.\nowner: me\nteam: docs\n---\nBody\n")
+
+	b, err := New(root, []validator.Result{validate(t, skill)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(root, ".sklint-baseline.json")
+	if err := b.Write(file); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Findings) != 2 || loaded.Findings[0].File != "skills/legacy/SKILL.md" {
+		t.Fatalf("unexpected baseline %#v", loaded.Findings)
+	}
+
+	// Shift every line down, fix one finding and add a new one.
+	writeSkill(t, skill, "---\nname: legacy\n\ndescription: Old.\nteam: docs\nrepo: x\n---\nBody\n")
+	result, matched, err := loaded.Apply(validate(t, skill), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixed := loaded.Unmatched(matched)
+	if len(result.Warnings) != 1 || result.Warnings[0].Message != "Unknown top-level key 'repo'." {
+		t.Fatalf("expected only the new finding, got %#v", result.Warnings)
+	}
+	want := []Entry{{Code: "UNKNOWN_TOP_LEVEL_KEY", File: "skills/legacy/SKILL.md", Fingerprint: Fingerprint(validator.Finding{Code: "UNKNOWN_TOP_LEVEL_KEY", Message: "Unknown top-level key 'owner'."})}}
+	if !reflect.DeepEqual(fixed, want) {
+		t.Fatalf("expected fixed %#v, got %#v", want, fixed)
+	}
+}
+
+func TestApplyRecomputesValid(t *testing.T) {
+	root := t.TempDir()
+	skill := filepath.Join(root, "broken")
+	writeSkill(t, skill, "---\nname: Broken\ndescription: Old.\n---\nBody\n")
+	result := validate(t, skill)
+	if result.Valid {
+		t.Fatal("expected an invalid skill")
+	}
+	b, err := New(root, []validator.Result{result})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, matched, err := b.Apply(result, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || len(result.Errors) != 0 {
+		t.Fatalf("expected baselined errors to be dropped, got %#v", result)
+	}
+
+	other := filepath.Join(root, "other")
+	writeSkill(t, other, "---\nname: other\ndescription: New.\n---\nBody\n")
+	_, more, err := b.Apply(validate(t, other), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixed := b.Unmatched(append(matched, more...)); len(fixed) != 0 {
+		t.Fatalf("expected every entry to match, got %#v", fixed)
+	}
+}
+
+func TestUnmatchedRemovedSkill(t *testing.T) {
+	root := t.TempDir()
+	kept := filepath.Join(root, "skills", "kept")
+	writeSkill(t, kept, "---\nname: kept\ndescription: Old.\nowner: me\n---\nBody\n")
+	removed := filepath.Join(root, "skills", "removed")
+	writeSkill(t, removed, "---\nname: removed\ndescription: Old.\nowner: me\n---\nBody\n")
+	b, err := New(root, []validator.Result{validate(t, kept), validate(t, removed)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.RemoveAll(removed); err != nil {
+		t.Fatal(err)
+	}
+	_, matched, err := b.Apply(validate(t, kept), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixed := b.Unmatched(matched)
+	if len(fixed) != 1 || fixed[0].File != "skills/removed/SKILL.md" {
+		t.Fatalf("expected the entry of the removed skill, got %#v", fixed)
+	}
+}
+
+func TestFingerprintIgnoresLineShifts(t *testing.T) {
+	root := t.TempDir()
+	duplicate := filepath.Join(root, "duplicate")
+	writeSkill(t, duplicate, "---\nname: duplicate\ndescription: Old.\ndescription: Again.\n---\nBody\n")
+	long := filepath.Join(root, "long")
+	writeSkill(t, long, "---\nname: long\ndescription: Old.\n---\n"+strings.Repeat("Body\n", 500))
+
+	b, err := New(root, []validator.Result{validate(t, duplicate), validate(t, long)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Findings) != 2 {
+		t.Fatalf("expected 2 recorded findings, got %#v", b.Findings)
+	}
+
+	writeSkill(t, duplicate, "---\nname: duplicate\n\ndescription: Old.\ndescription: Again.\n---\nBody\n")
+	writeSkill(t, long, "---\nname: long\ndescription: Old.\n---\n"+strings.Repeat("Body\n", 501))
+	var matched []Entry
+	for _, skill := range []string{duplicate, long} {
+		result, m, err := b.Apply(validate(t, skill), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Errors) != 0 || len(result.Warnings) != 0 {
+			t.Fatalf("expected shifted findings of %s to match, got %#v", skill, result)
+		}
+		matched = append(matched, m...)
+	}
+	if fixed := b.Unmatched(matched); len(fixed) != 0 {
+		t.Fatalf("expected every entry to match, got %#v", fixed)
+	}
+}
+
+func TestLoadRejectsUnknownVersion(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(file, []byte(`{"version": 1, "findings": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(file); err == nil {
+		t.Fatal("expected an error for an unknown version")
+	}
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sven1103-agent/sklint/internal/spdx"
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// FileName is the name of the project configuration file looked up from each
+// skill directory upward.
+const FileName = ".sklint.yaml"
+
+type Config struct {
+	Path           string            `yaml:"-"`
+	Strict         *bool             `yaml:"strict"`
+	Format         string            `yaml:"format"`
+	FollowSymlinks *bool             `yaml:"follow-symlinks"`
+	MaxArchiveSize int64             `yaml:"max-archive-size"`
+	Portability    *bool             `yaml:"portability"`
+	KnownTools     []string          `yaml:"known-tools"`
+	Licenses       LicensePolicy     `yaml:"licenses"`
+	Metadata       MetadataSchema    `yaml:"metadata"`
+	Rules          map[string]string `yaml:"rules"`
+}
+
+// LicensePolicy lists the SPDX license identifiers skills may and may not
+// use.
+type LicensePolicy struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
+// MetadataSchema declares the keys skills must and may use in metadata.
+type MetadataSchema struct {
+	Keys map[string]MetadataKey `yaml:"keys"`
+	// UnknownKeys is "allow", the default, or "reject".
+	UnknownKeys string `yaml:"unknown-keys"`
+}
+
+type MetadataKey struct {
+	Required bool     `yaml:"required"`
+	Pattern  string   `yaml:"pattern"`
+	Enum     []string `yaml:"enum"`
+}
+
+// Find returns the path of the nearest configuration file in dir or one of its
+// parents, or an empty string if there is none.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		candidate := filepath.Join(dir, FileName)
+		info, err := os.Stat(candidate)
+		if err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads and validates the configuration file at path.
+func Load(path string) (Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg, err := parse(content)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	cfg.Path = path
+	return cfg, nil
+}
+
+func parse(content []byte) (Config, error) {
+	var cfg Config
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, err
+	}
+	if _, err := cfg.Severity(); err != nil {
+		return Config{}, err
+	}
+	if _, err := cfg.MetadataSchema(); err != nil {
+		return Config{}, err
+	}
+	for _, id := range append(append([]string(nil), cfg.Licenses.Allow...), cfg.Licenses.Deny...) {
+		if expr, err := spdx.Parse(id); err != nil || expr.Op != "" || expr.OrLater || expr.Exception != "" {
+			return Config{}, fmt.Errorf("licenses: %q is not an SPDX license identifier", id)
+		}
+	}
+	return cfg, nil
+}
+
+// Severity converts the rules section into validator severity overrides.
+func (c Config) Severity() (map[string]validator.FindingLevel, error) {
+	if len(c.Rules) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]struct{})
+	for _, info := range validator.Codes() {
+		known[info.Code] = struct{}{}
+	}
+
+	codes := make([]string, 0, len(c.Rules))
+	for code := range c.Rules {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	severity := make(map[string]validator.FindingLevel, len(c.Rules))
+	for _, code := range codes {
+		if _, ok := known[code]; !ok {
+			return nil, fmt.Errorf("unknown rule code %q", code)
+		}
+		level := validator.FindingLevel(c.Rules[code])
+		switch level {
+		case validator.LevelError, validator.LevelWarning, validator.LevelOff:
+			severity[code] = level
+		default:
+			return nil, fmt.Errorf("rule %s: severity must be error, warning or off, got %q", code, c.Rules[code])
+		}
+	}
+	return severity, nil
+}
+
+// MetadataSchema converts the metadata section into a validator schema, or
+// nil when the section is empty.
+func (c Config) MetadataSchema() (*validator.MetadataSchema, error) {
+	schema := &validator.MetadataSchema{Keys: make(map[string]validator.MetadataKey, len(c.Metadata.Keys))}
+	switch c.Metadata.UnknownKeys {
+	case "", "allow":
+	case "reject":
+		schema.RejectUnknown = true
+	default:
+		return nil, fmt.Errorf("metadata: unknown-keys must be allow or reject, got %q", c.Metadata.UnknownKeys)
+	}
+	if len(c.Metadata.Keys) == 0 && !schema.RejectUnknown {
+		return nil, nil
+	}
+	for name, key := range c.Metadata.Keys {
+		rule := validator.MetadataKey{Required: key.Required, Enum: key.Enum}
+		if key.Pattern != "" {
+			pattern, err := regexp.Compile(key.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("metadata: key %s: %w", name, err)
+			}
+			rule.Pattern = pattern
+		}
+		schema.Keys[name] = rule
+	}
+	return schema, nil
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func TestFindWalksUpward(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "skills", "my-skill")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	configPath := filepath.Join(root, FileName)
+	if err := os.WriteFile(configPath, []byte("strict: true\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	found, err := Find(nested)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found != configPath {
+		t.Fatalf("expected %s, got %s", configPath, found)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	content := "strict: true\nformat: json\nfollow-symlinks: false\nmax-archive-size: 1048576\nportability: true\nknown-tools: [Read, Deploy]\nlicenses:\n  allow: [MIT, LicenseRef-Internal]\nrules:\n  REF_TOO_DEEP: off\n  UNKNOWN_TOP_LEVEL_KEY: error\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Path != path || cfg.Strict == nil || !*cfg.Strict || cfg.Format != "json" {
+		t.Fatalf("unexpected config: %#v", cfg)
+	}
+	if cfg.FollowSymlinks == nil || *cfg.FollowSymlinks {
+		t.Fatalf("expected follow-symlinks to be set to false: %#v", cfg)
+	}
+	if cfg.MaxArchiveSize != 1<<20 || cfg.Portability == nil || !*cfg.Portability || len(cfg.KnownTools) != 2 || len(cfg.Licenses.Allow) != 2 {
+		t.Fatalf("expected max-archive-size, portability, known-tools and licenses to be set: %#v", cfg)
+	}
+	severity, err := cfg.Severity()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if severity["REF_TOO_DEEP"] != validator.LevelOff || severity["UNKNOWN_TOP_LEVEL_KEY"] != validator.LevelError {
+		t.Fatalf("unexpected severity: %#v", severity)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+	}{
+		{"unknown-field", "stict: true\n"},
+		{"unknown-code", "rules:\n  NOT_A_CODE: off\n"},
+		{"bad-severity", "rules:\n  REF_TOO_DEEP: loud\n"},
+		{"unknown-license", "licenses:\n  allow: [MITT]\n"},
+		{"license-expression", "licenses:\n  deny: [MIT OR ISC]\n"},
+		{"bad-pattern", "metadata:\n  keys:\n    version: {pattern: '('}\n"},
+		{"bad-unknown-keys", "metadata:\n  unknown-keys: warn\n"},
+	}
+	for _, tc := range cases {
+		if _, err := parse([]byte(tc.content)); err == nil {
+			t.Fatalf("%s: expected error", tc.name)
+		}
+	}
+}
+
+func TestMetadataSchema(t *testing.T) {
+	cfg, err := parse([]byte("metadata:\n  keys:\n    version: {required: true, pattern: '^\\d+$'}\n    team: {enum: [docs]}\n  unknown-keys: reject\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema, err := cfg.MetadataSchema()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	version := schema.Keys["version"]
+	if !schema.RejectUnknown || !version.Required || !version.Pattern.MatchString("12") || len(schema.Keys["team"].Enum) != 1 {
+		t.Fatalf("unexpected schema: %#v", schema)
+	}
+
+	if schema, err := (Config{}).MetadataSchema(); err != nil || schema != nil {
+		t.Fatalf("expected no schema, got %#v, %v", schema, err)
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	cfg, err := parse(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Strict != nil || cfg.Format != "" || len(cfg.Rules) != 0 {
+		t.Fatalf("expected empty config, got %#v", cfg)
+	}
+}
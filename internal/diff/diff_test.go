@@ -0,0 +1,38 @@
+package diff
+
+import "testing"
+
+func TestUnifiedEqual(t *testing.T) {
+	if out := Unified("a", "b", "same\n", "same\n"); out != "" {
+		t.Fatalf("expected empty diff, got %q", out)
+	}
+}
+
+func TestUnifiedSingleChange(t *testing.T) {
+	a := "---\nname: Foo\ndescription: d\n---\nbody\n"
+	b := "---\nname: foo\ndescription: d\n---\nbody\n"
+	want := "--- a/SKILL.md\n+++ b/SKILL.md\n" +
+		"@@ -1,5 +1,5 @@\n" +
+		" ---\n-name: Foo\n+name: foo\n description: d\n ---\n body\n"
+	if out := Unified("a/SKILL.md", "b/SKILL.md", a, b); out != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestUnifiedSeparateHunks(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n"
+	want := "--- a\n+++ b\n" +
+		"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+		"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n"
+	if out := Unified("a", "b", a, b); out != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestUnifiedInsertion(t *testing.T) {
+	want := "--- a\n+++ b\n@@ -0,0 +1 @@\n+new\n"
+	if out := Unified("a", "b", "", "new\n"); out != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -0,0 +1,457 @@
+// Package lsp implements a language server for SKILL.md files that speaks the
+// Language Server Protocol over a pair of streams, usually stdin and stdout.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// OptionsFunc returns the validation options for the skill directory dir,
+// typically after resolving the configuration that applies to it.
+type OptionsFunc func(dir string) (validator.Options, error)
+
+var (
+	frontmatterKeyPattern = regexp.MustCompile(`^([A-Za-z0-9_-]+)\s*:`)
+	codeWordPattern       = regexp.MustCompile(`[A-Z][A-Z0-9_]*`)
+)
+
+// document is an open SKILL.md buffer and the findings of its last
+// validation.
+type document struct {
+	text     string
+	findings []validator.Finding
+}
+
+// Server answers requests from a single client. Documents are validated from
+// the editor's buffer, so diagnostics follow unsaved changes.
+type Server struct {
+	in       *bufio.Reader
+	out      io.Writer
+	options  OptionsFunc
+	docs     map[string]*document
+	shutdown bool
+}
+
+// NewServer returns a server reading messages from in and writing to out.
+func NewServer(in io.Reader, out io.Writer, options OptionsFunc) *Server {
+	return &Server{
+		in:      bufio.NewReader(in),
+		out:     out,
+		options: options,
+		docs:    make(map[string]*document),
+	}
+}
+
+// Run serves requests until the client sends exit. It returns an error when
+// the stream breaks or the client exits without shutting down first.
+func (s *Server) Run() error {
+	for {
+		body, err := readMessage(s.in)
+		if err != nil {
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			return fmt.Errorf("invalid message: %w", err)
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit without shutdown")
+			}
+			return nil
+		}
+		if err := s.handle(req); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(req request) error {
+	var result any
+	var respErr *responseError
+	switch req.Method {
+	case "initialize":
+		result = initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync: textDocumentSyncOptions{
+					OpenClose: true,
+					Change:    syncFull,
+					Save:      saveOptions{IncludeText: true},
+				},
+				CompletionProvider: completionOptions{},
+				HoverProvider:      true,
+			},
+			ServerInfo: serverInfo{Name: "sklint"},
+		}
+	case "shutdown":
+		s.shutdown = true
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil
+		}
+		return s.update(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params.ContentChanges) == 0 {
+			return nil
+		}
+		return s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+	case "textDocument/didSave":
+		var params didSaveParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil
+		}
+		text := ""
+		if params.Text != nil {
+			text = *params.Text
+		} else if doc, ok := s.docs[params.TextDocument.URI]; ok {
+			text = doc.text
+		}
+		return s.update(params.TextDocument.URI, text)
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []diagnostic{},
+		})
+	case "textDocument/completion":
+		var params textDocumentPositionParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			respErr = &responseError{Code: errInvalidParams, Message: err.Error()}
+			break
+		}
+		result = s.completion(params)
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			respErr = &responseError{Code: errInvalidParams, Message: err.Error()}
+			break
+		}
+		if h := s.hover(params); h != nil {
+			result = h
+		}
+	default:
+		if req.ID == nil {
+			return nil
+		}
+		respErr = &responseError{Code: errMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+	if req.ID == nil {
+		return nil
+	}
+	return s.write(response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: respErr})
+}
+
+// update validates the new text of a document and publishes its diagnostics.
+// Only files named SKILL.md are validated.
+func (s *Server) update(uri, text string) error {
+	doc := &document{text: text}
+	s.docs[uri] = doc
+
+	path, ok := uriToPath(uri)
+	if !ok || filepath.Base(path) != "SKILL.md" {
+		return nil
+	}
+	dir := filepath.Dir(path)
+	opts, err := s.options(dir)
+	if err != nil {
+		return s.notify("window/showMessage", showMessageParams{Type: messageTypeError, Message: err.Error()})
+	}
+	result, err := validator.ValidateContent(dir, []byte(text), opts)
+	if err != nil {
+		return s.notify("window/showMessage", showMessageParams{Type: messageTypeError, Message: err.Error()})
+	}
+	doc.findings = append(append(doc.findings, result.Errors...), result.Warnings...)
+
+	lines := strings.Split(text, "\n")
+	diagnostics := make([]diagnostic, 0, len(doc.findings))
+	for _, finding := range doc.findings {
+		severity := severityWarning
+		if finding.Level == validator.LevelError {
+			severity = severityError
+		}
+		diagnostics = append(diagnostics, diagnostic{
+			Range:    findingRange(lines, finding),
+			Severity: severity,
+			Code:     finding.Code,
+			Source:   "sklint",
+			Message:  finding.Message,
+		})
+	}
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+}
+
+// completion offers the specification's top-level keys that are not yet
+// present when the cursor is at the start of a frontmatter line.
+func (s *Server) completion(params textDocumentPositionParams) []completionItem {
+	items := make([]completionItem, 0)
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return items
+	}
+	lines := strings.Split(doc.text, "\n")
+	end := frontmatterEnd(lines)
+	line := params.Position.Line
+	if line <= 0 || line >= len(lines) || (end >= 0 && line >= end) {
+		return items
+	}
+	prefix := utf16Prefix(strings.TrimSuffix(lines[line], "\r"), params.Position.Character)
+	if strings.ContainsAny(prefix, ": \t#") {
+		return items
+	}
+
+	present := make(map[string]bool)
+	for i := 1; i < len(lines) && (end < 0 || i < end); i++ {
+		if i == line {
+			continue
+		}
+		if match := frontmatterKeyPattern.FindStringSubmatch(lines[i]); match != nil {
+			present[match[1]] = true
+		}
+	}
+	for _, key := range validator.KnownKeys() {
+		if present[key] || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		items = append(items, completionItem{
+			Label:      key,
+			Kind:       completionKindProperty,
+			Detail:     "SKILL.md frontmatter key",
+			InsertText: key + ": ",
+		})
+	}
+	return items
+}
+
+// hover documents the finding codes at a position: the code under the
+// cursor, such as one named in a suppression comment, or else the
+// diagnostics covering the position.
+func (s *Server) hover(params textDocumentPositionParams) *hover {
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+	lines := strings.Split(doc.text, "\n")
+	if params.Position.Line < 0 || params.Position.Line >= len(lines) {
+		return nil
+	}
+	infos := make(map[string]validator.CodeInfo)
+	for _, info := range validator.Codes() {
+		infos[info.Code] = info
+	}
+
+	text := strings.TrimSuffix(lines[params.Position.Line], "\r")
+	offset := len(utf16Prefix(text, params.Position.Character))
+	for _, match := range codeWordPattern.FindAllStringIndex(text, -1) {
+		if offset < match[0] || offset > match[1] {
+			continue
+		}
+		info, ok := infos[text[match[0]:match[1]]]
+		if !ok {
+			continue
+		}
+		r := lspRange{
+			Start: position{Line: params.Position.Line, Character: utf16Len(text[:match[0]])},
+			End:   position{Line: params.Position.Line, Character: utf16Len(text[:match[1]])},
+		}
+		return &hover{Contents: markupContent{Kind: markupKindMarkdown, Value: codeDoc(info)}, Range: &r}
+	}
+
+	sections := make([]string, 0)
+	for _, finding := range doc.findings {
+		r := findingRange(lines, finding)
+		if !r.contains(params.Position) {
+			continue
+		}
+		section := fmt.Sprintf("**%s** (%s)\n\n%s", finding.Code, finding.Level, finding.Message)
+		if info, ok := infos[finding.Code]; ok {
+			section += "\n\n" + info.Description
+		}
+		sections = append(sections, section)
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	return &hover{Contents: markupContent{Kind: markupKindMarkdown, Value: strings.Join(sections, "\n\n---\n\n")}}
+}
+
+func codeDoc(info validator.CodeInfo) string {
+	return fmt.Sprintf("**%s** (%s)\n\n%s", info.Code, info.Level, info.Description)
+}
+
+func (r lspRange) contains(p position) bool {
+	if p.Line < r.Start.Line || p.Line > r.End.Line {
+		return false
+	}
+	if p.Line == r.Start.Line && p.Character < r.Start.Character {
+		return false
+	}
+	if p.Line == r.End.Line && p.Character > r.End.Character {
+		return false
+	}
+	return true
+}
+
+// findingRange converts the 1-based, character-counted span of a finding to
+// a 0-based LSP range counted in UTF-16 code units. Findings without a line
+// are placed at the start of the document; findings without a column cover
+// their whole line.
+func findingRange(lines []string, finding validator.Finding) lspRange {
+	if finding.Line == 0 {
+		return lspRange{}
+	}
+	endLine := finding.EndLine
+	if endLine == 0 {
+		endLine = finding.Line
+	}
+	start := position{Line: finding.Line - 1, Character: runeColumn(lines, finding.Line, finding.Column)}
+	end := position{Line: endLine - 1, Character: runeColumn(lines, endLine, finding.EndColumn)}
+	if finding.Column == 0 {
+		start.Character = 0
+		end.Character = runeColumn(lines, endLine, -1)
+	}
+	return lspRange{Start: start, End: end}
+}
+
+// runeColumn returns the UTF-16 offset of the 1-based character column on
+// the 1-based line, or of the end of the line when column is negative.
+func runeColumn(lines []string, line, column int) int {
+	if line < 1 || line > len(lines) {
+		return 0
+	}
+	text := strings.TrimSuffix(lines[line-1], "\r")
+	if line == 1 {
+		text = strings.TrimPrefix(text, "\ufeff")
+	}
+	if column < 0 {
+		return utf16Len(text)
+	}
+	offset := 0
+	for i := 1; i < column && offset < len(text); i++ {
+		_, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+	}
+	return utf16Len(text[:offset])
+}
+
+// utf16Prefix returns the part of text before the given UTF-16 offset.
+func utf16Prefix(text string, character int) string {
+	units := 0
+	for i, r := range text {
+		if units >= character {
+			return text[:i]
+		}
+		units += utf16Width(r)
+	}
+	return text
+}
+
+func utf16Len(text string) int {
+	units := 0
+	for _, r := range text {
+		units += utf16Width(r)
+	}
+	return units
+}
+
+func utf16Width(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// frontmatterEnd returns the index of the closing delimiter line, or -1 when
+// the frontmatter is not closed yet. It returns 0 when the document does not
+// start with frontmatter.
+func frontmatterEnd(lines []string) int {
+	if len(lines) == 0 || strings.TrimSpace(strings.TrimPrefix(lines[0], "\ufeff")) != "---" {
+		return 0
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return i
+		}
+	}
+	return -1
+}
+
+func uriToPath(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+	return filepath.FromSlash(path), true
+}
+
+func (s *Server) notify(method string, params any) error {
+	return s.write(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (s *Server) write(message any) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = s.out.Write(body)
+	return err
+}
+
+// readMessage reads one base-protocol message: headers terminated by an
+// empty line, then Content-Length bytes of JSON.
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header: %q", line)
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length: %q", value)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("missing Content-Length header")
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,307 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+type message struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+func frame(t *testing.T, messages ...map[string]any) *bytes.Buffer {
+	t.Helper()
+	var in bytes.Buffer
+	for _, m := range messages {
+		m["jsonrpc"] = "2.0"
+		body, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+	return &in
+}
+
+// serve runs a session that ends with shutdown and exit and returns every
+// message the server wrote.
+func serve(t *testing.T, messages ...map[string]any) []message {
+	t.Helper()
+	messages = append(messages,
+		map[string]any{"id": 999, "method": "shutdown"},
+		map[string]any{"method": "exit"},
+	)
+	var out bytes.Buffer
+	options := func(string) (validator.Options, error) {
+		return validator.Options{CheckRefsExist: true}, nil
+	}
+	if err := NewServer(frame(t, messages...), &out, options).Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	replies := make([]message, 0)
+	r := bufio.NewReader(&out)
+	for {
+		body, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		var m message
+		if err := json.Unmarshal(body, &m); err != nil {
+			t.Fatal(err)
+		}
+		replies = append(replies, m)
+	}
+	return replies
+}
+
+func skillURI(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(dir, "SKILL.md"))}).String()
+}
+
+func open(uri, text string) map[string]any {
+	return map[string]any{
+		"method": "textDocument/didOpen",
+		"params": map[string]any{"textDocument": map[string]any{"uri": uri, "languageId": "markdown", "version": 1, "text": text}},
+	}
+}
+
+func lastDiagnostics(t *testing.T, replies []message) publishDiagnosticsParams {
+	t.Helper()
+	var params publishDiagnosticsParams
+	found := false
+	for _, m := range replies {
+		if m.Method == "textDocument/publishDiagnostics" {
+			found = true
+			if err := json.Unmarshal(m.Params, &params); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("no diagnostics published")
+	}
+	return params
+}
+
+func reply(t *testing.T, replies []message, id int) message {
+	t.Helper()
+	for _, m := range replies {
+		if m.ID != nil && *m.ID == id {
+			return m
+		}
+	}
+	t.Fatalf("no reply to request %d", id)
+	return message{}
+}
+
+func TestInitialize(t *testing.T) {
+	replies := serve(t, map[string]any{"id": 1, "method": "initialize", "params": map[string]any{}})
+	var result initializeResult
+	if err := json.Unmarshal(reply(t, replies, 1).Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Capabilities.TextDocumentSync.Change != syncFull || !result.Capabilities.HoverProvider {
+		t.Fatalf("unexpected capabilities: %+v", result.Capabilities)
+	}
+}
+
+func TestExitWithoutShutdown(t *testing.T) {
+	var out bytes.Buffer
+	err := NewServer(frame(t, map[string]any{"method": "exit"}), &out, nil).Run()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestUnknownRequest(t *testing.T) {
+	replies := serve(t, map[string]any{"id": 1, "method": "workspace/symbol", "params": map[string]any{}})
+	if m := reply(t, replies, 1); m.Error == nil || m.Error.Code != errMethodNotFound {
+		t.Fatalf("expected method not found, got %+v", m)
+	}
+}
+
+func TestDiagnosticsFromBuffer(t *testing.T) {
+	uri := skillURI(t)
+	text := "---\nname: Other_Skill\ndescription: Does things.\n---\n\nBody\n"
+	diagnostics := lastDiagnostics(t, serve(t, open(uri, text)))
+	if diagnostics.URI != uri {
+		t.Fatalf("unexpected uri %q", diagnostics.URI)
+	}
+
+	var got *diagnostic
+	for i, d := range diagnostics.Diagnostics {
+		if d.Code == "NAME_INVALID_CHARS" {
+			got = &diagnostics.Diagnostics[i]
+		}
+	}
+	if got == nil {
+		t.Fatalf("expected NAME_INVALID_CHARS, got %+v", diagnostics.Diagnostics)
+	}
+	want := lspRange{Start: position{Line: 1, Character: 6}, End: position{Line: 1, Character: 17}}
+	if got.Range != want || got.Severity != severityError || got.Source != "sklint" {
+		t.Fatalf("unexpected diagnostic %+v", got)
+	}
+}
+
+func TestDiagnosticsFollowChanges(t *testing.T) {
+	uri := skillURI(t)
+	change := map[string]any{
+		"method": "textDocument/didChange",
+		"params": map[string]any{
+			"textDocument":   map[string]any{"uri": uri, "version": 2},
+			"contentChanges": []map[string]any{{"text": "---\nname: my-skill\ndescription: Does things.\n---\n\nBody\n"}},
+		},
+	}
+	diagnostics := lastDiagnostics(t, serve(t, open(uri, "no frontmatter\n"), change))
+	if len(diagnostics.Diagnostics) != 0 {
+		t.Fatalf("expected no diagnostics, got %+v", diagnostics.Diagnostics)
+	}
+}
+
+func TestDiagnosticsClearedOnClose(t *testing.T) {
+	uri := skillURI(t)
+	closeDoc := map[string]any{
+		"method": "textDocument/didClose",
+		"params": map[string]any{"textDocument": map[string]any{"uri": uri}},
+	}
+	diagnostics := lastDiagnostics(t, serve(t, open(uri, "no frontmatter\n"), closeDoc))
+	if len(diagnostics.Diagnostics) != 0 {
+		t.Fatalf("expected diagnostics to be cleared, got %+v", diagnostics.Diagnostics)
+	}
+}
+
+func TestOtherFilesAreNotValidated(t *testing.T) {
+	uri := strings.TrimSuffix(skillURI(t), "SKILL.md") + "README.md"
+	for _, m := range serve(t, open(uri, "no frontmatter\n")) {
+		if m.Method == "textDocument/publishDiagnostics" {
+			t.Fatalf("unexpected diagnostics for %s", uri)
+		}
+	}
+}
+
+func TestFindingRangeUsesUTF16(t *testing.T) {
+	lines := []string{"---", "name: 😀ab", "---"}
+	finding := validator.Finding{Line: 2, Column: 8, EndLine: 2, EndColumn: 10}
+	want := lspRange{Start: position{Line: 1, Character: 8}, End: position{Line: 1, Character: 10}}
+	if got := findingRange(lines, finding); got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if got := findingRange(lines, validator.Finding{}); got != (lspRange{}) {
+		t.Fatalf("expected empty range, got %+v", got)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	uri := skillURI(t)
+	text := "---\nname: my-skill\nd\n---\n\nBody\n"
+	replies := serve(t, open(uri, text), map[string]any{
+		"id":     2,
+		"method": "textDocument/completion",
+		"params": map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": 2, "character": 1}},
+	}, map[string]any{
+		"id":     3,
+		"method": "textDocument/completion",
+		"params": map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": 5, "character": 0}},
+	})
+
+	var items []completionItem
+	if err := json.Unmarshal(reply(t, replies, 2).Result, &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Label != "description" || items[0].InsertText != "description: " {
+		t.Fatalf("unexpected completion items %+v", items)
+	}
+
+	if err := json.Unmarshal(reply(t, replies, 3).Result, &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("expected no completion in the body, got %+v", items)
+	}
+}
+
+func TestCompletionSkipsPresentKeys(t *testing.T) {
+	uri := skillURI(t)
+	text := "---\nname: my-skill\n\n---\n"
+	replies := serve(t, open(uri, text), map[string]any{
+		"id":     2,
+		"method": "textDocument/completion",
+		"params": map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": 2, "character": 0}},
+	})
+	var items []completionItem
+	if err := json.Unmarshal(reply(t, replies, 2).Result, &items); err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range items {
+		if item.Label == "name" {
+			t.Fatal("name should not be offered again")
+		}
+	}
+	if len(items) != len(validator.KnownKeys())-1 {
+		t.Fatalf("unexpected completion items %+v", items)
+	}
+}
+
+func TestHover(t *testing.T) {
+	uri := skillURI(t)
+	text := "---\nname: my-skill\ndescription: Does things.\nx-owner: me # sklint-disable UNKNOWN_TOP_LEVEL_KEY\nextra: 1\n---\n\nBody\n"
+	hoverAt := func(id, line, character int) map[string]any {
+		return map[string]any{
+			"id":     id,
+			"method": "textDocument/hover",
+			"params": map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": line, "character": character}},
+		}
+	}
+	replies := serve(t, open(uri, text), hoverAt(2, 3, 35), hoverAt(3, 1, 3))
+
+	var h hover
+	if err := json.Unmarshal(reply(t, replies, 2).Result, &h); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(h.Contents.Value, "**UNKNOWN_TOP_LEVEL_KEY** (warning)") {
+		t.Fatalf("unexpected hover %q", h.Contents.Value)
+	}
+	if h.Range == nil || h.Range.Start.Character != 29 || h.Range.End.Character != 50 {
+		t.Fatalf("unexpected hover range %+v", h.Range)
+	}
+
+	if m := reply(t, replies, 3); string(m.Result) != "null" {
+		t.Fatalf("expected no hover, got %s", m.Result)
+	}
+}
+
+func TestHoverOnDiagnostic(t *testing.T) {
+	uri := skillURI(t)
+	text := "---\nname: Other_Skill\ndescription: Does things.\n---\n\nBody\n"
+	replies := serve(t, open(uri, text), map[string]any{
+		"id":     2,
+		"method": "textDocument/hover",
+		"params": map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": 1, "character": 8}},
+	})
+	var h hover
+	if err := json.Unmarshal(reply(t, replies, 2).Result, &h); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(h.Contents.Value, "**NAME_INVALID_CHARS** (error)") {
+		t.Fatalf("unexpected hover %q", h.Contents.Value)
+	}
+}
@@ -0,0 +1,77 @@
+package pack
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"strings"
+)
+
+// IgnoreFileName is the file in a skill directory listing paths to leave out
+// of the bundle.
+const IgnoreFileName = ".sklintignore"
+
+// ignoreRule is one line of an ignore file. The syntax is a subset of
+// .gitignore: path.Match globs, "!" to re-include, a trailing "/" to match
+// only directories, and a "/" anywhere but the end to anchor the pattern to
+// the skill directory instead of matching base names at any depth.
+type ignoreRule struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+type ignoreRules []ignoreRule
+
+func parseIgnore(r io.Reader) (ignoreRules, error) {
+	rules := make(ignoreRules, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var rule ignoreRule
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		if _, err := path.Match(line, ""); err != nil {
+			return nil, err
+		}
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+	return rules, scanner.Err()
+}
+
+// ignored reports whether the slash-separated path name, relative to the
+// skill directory, is excluded. The last matching rule wins.
+func (rules ignoreRules) ignored(name string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		subject := path.Base(name)
+		if rule.anchored {
+			subject = name
+		}
+		if ok, _ := path.Match(rule.pattern, subject); ok {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
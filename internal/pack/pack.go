@@ -0,0 +1,177 @@
+// Package pack builds reproducible zip bundles of skill directories.
+package pack
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ManifestName is the file added to the root of the bundle listing the
+// SHA-256 hash of every other file, in the format of sha256sum, so that
+// "sha256sum -c MANIFEST.sha256" run inside the skill directory verifies it.
+const ManifestName = "MANIFEST.sha256"
+
+// modTime is the timestamp of every entry: the earliest time a zip file can
+// represent, so that bundles do not depend on when files were touched.
+var modTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// File is a file of the skill to pack.
+type File struct {
+	// Name is the slash-separated path relative to the skill directory.
+	Name string
+	// Executable is set when any execute bit is set on disk.
+	Executable bool
+}
+
+// Collect lists the files of the skill directory dir to bundle, sorted by
+// name. Hidden files and directories, files matched by the ignore file,
+// any existing manifest and the paths in exclude are left out. Symlinks
+// are refused because the bundle must not depend on anything outside it.
+func Collect(dir string, exclude ...string) ([]File, error) {
+	rules := ignoreRules{}
+	if f, err := os.Open(filepath.Join(dir, IgnoreFileName)); err == nil {
+		rules, err = parseIgnore(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Join(dir, IgnoreFileName), err)
+		}
+	}
+	excluded := make(map[string]bool)
+	for _, name := range exclude {
+		if abs, err := filepath.Abs(name); err == nil {
+			excluded[abs] = true
+		}
+	}
+
+	files := make([]File, 0)
+	err := filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+		skip := strings.HasPrefix(entry.Name(), ".") || rules.ignored(name, entry.IsDir()) || name == ManifestName
+		if abs, err := filepath.Abs(p); err == nil && excluded[abs] {
+			skip = true
+		}
+		if skip {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		if entry.Type()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink; replace it with the file it points to before packing", p)
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Name: name, Executable: info.Mode()&0o111 != 0})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files, nil
+}
+
+// Zip writes a bundle of files from dir to w. Entries sit below a single
+// directory named like the skill directory, appear in sorted order with
+// their parent directories first, and carry a fixed timestamp and 0644,
+// 0755 for executables and directories, as permissions. It returns the
+// manifest it embedded.
+func Zip(w io.Writer, dir string, files []File) ([]byte, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Base(abs)
+
+	var manifest strings.Builder
+	contents := make(map[string][]byte, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file.Name)))
+		if err != nil {
+			return nil, err
+		}
+		contents[file.Name] = data
+		sum := sha256.Sum256(data)
+		fmt.Fprintf(&manifest, "%s  %s\n", hex.EncodeToString(sum[:]), file.Name)
+	}
+	entries := append([]File{{Name: ManifestName}}, files...)
+	contents[ManifestName] = []byte(manifest.String())
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	zw := zip.NewWriter(w)
+	written := map[string]bool{}
+	for _, entry := range entries {
+		if err := writeDirs(zw, root, path.Dir(entry.Name), written); err != nil {
+			return nil, err
+		}
+		header := &zip.FileHeader{Name: root + "/" + entry.Name, Method: zip.Deflate, Modified: modTime}
+		header.SetMode(0o644)
+		if entry.Executable {
+			header.SetMode(0o755)
+		}
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write(contents[entry.Name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return contents[ManifestName], nil
+}
+
+// writeDirs adds entries for dir and its parents below root unless they
+// have been written already.
+func writeDirs(zw *zip.Writer, root, dir string, written map[string]bool) error {
+	if dir == "." {
+		dir = ""
+	}
+	parts := []string{root}
+	if dir != "" {
+		parts = append(parts, strings.Split(dir, "/")...)
+	}
+	for i := range parts {
+		name := strings.Join(parts[:i+1], "/") + "/"
+		if written[name] {
+			continue
+		}
+		written[name] = true
+		header := &zip.FileHeader{Name: name, Method: zip.Store, Modified: modTime}
+		header.SetMode(fs.ModeDir | 0o755)
+		if _, err := zw.CreateHeader(header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
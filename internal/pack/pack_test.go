@@ -0,0 +1,232 @@
+package pack
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func skillDir(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	writeFiles(t, dir, map[string]string{
+		"SKILL.md":               "---\nname: my-skill\ndescription: Packed.\n---\nRun [setup](scripts/setup.sh).\n",
+		"scripts/setup.sh":       "#!/bin/sh\necho setup\n",
+		"references/guide.md":    "# Guide\n",
+		"references/draft.md":    "# Draft\n",
+		"notes.tmp":              "scratch\n",
+		"build/out.txt":          "generated\n",
+		".env":                   "SECRET=1\n",
+		".git/config":            "[core]\n",
+		IgnoreFileName:           "# local files\n*.tmp\nbuild/\n/references/draft.md\n",
+		"assets/keep/draft.md":   "# Kept\n",
+		"assets/keep/.DS_Store":  "junk",
+		"assets/keep/notes.tmp":  "scratch\n",
+		"assets/keep/ok.tmp.txt": "kept\n",
+	})
+	if err := os.Chmod(filepath.Join(dir, "scripts", "setup.sh"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestCollect(t *testing.T) {
+	dir := skillDir(t)
+	files, err := Collect(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []File{
+		{Name: "SKILL.md"},
+		{Name: "assets/keep/draft.md"},
+		{Name: "assets/keep/ok.tmp.txt"},
+		{Name: "references/guide.md"},
+		{Name: "scripts/setup.sh", Executable: true},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Fatalf("expected %#v, got %#v", want, files)
+	}
+}
+
+func TestCollectExcludesOutput(t *testing.T) {
+	dir := skillDir(t)
+	output := filepath.Join(dir, "assets", "my-skill.zip")
+	writeFiles(t, dir, map[string]string{"assets/my-skill.zip": "old bundle"})
+	files, err := Collect(dir, output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, file := range files {
+		if file.Name == "assets/my-skill.zip" {
+			t.Fatal("output file should be excluded")
+		}
+	}
+}
+
+func TestCollectRefusesSymlinks(t *testing.T) {
+	dir := skillDir(t)
+	if err := os.Symlink("guide.md", filepath.Join(dir, "references", "link.md")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if _, err := Collect(dir); err == nil || !strings.Contains(err.Error(), "symlink") {
+		t.Fatalf("expected symlink error, got %v", err)
+	}
+}
+
+func TestZipIsReproducible(t *testing.T) {
+	dir := skillDir(t)
+	files, err := Collect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var first, second bytes.Buffer
+	if _, err := Zip(&first, dir, files); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "SKILL.md"), future, future); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Zip(&second, dir, files); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Fatal("bundles differ between runs")
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(first.Bytes()), int64(first.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(r.File))
+	for _, f := range r.File {
+		names = append(names, f.Name)
+		if !f.Modified.Equal(modTime) {
+			t.Fatalf("%s: unexpected timestamp %v", f.Name, f.Modified)
+		}
+		wantMode := os.FileMode(0o644)
+		switch {
+		case f.Mode().IsDir():
+			wantMode = os.ModeDir | 0o755
+		case f.Name == "my-skill/scripts/setup.sh":
+			wantMode = 0o755
+		}
+		if f.Mode() != wantMode {
+			t.Fatalf("%s: expected mode %v, got %v", f.Name, wantMode, f.Mode())
+		}
+	}
+	want := []string{
+		"my-skill/",
+		"my-skill/MANIFEST.sha256",
+		"my-skill/SKILL.md",
+		"my-skill/assets/",
+		"my-skill/assets/keep/",
+		"my-skill/assets/keep/draft.md",
+		"my-skill/assets/keep/ok.tmp.txt",
+		"my-skill/references/",
+		"my-skill/references/guide.md",
+		"my-skill/scripts/",
+		"my-skill/scripts/setup.sh",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("expected entries %v, got %v", want, names)
+	}
+}
+
+func TestZipManifest(t *testing.T) {
+	dir := skillDir(t)
+	files, err := Collect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := Zip(&bytes.Buffer{}, dir, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(manifest), "\n"), "\n")
+	if len(lines) != len(files) {
+		t.Fatalf("expected %d manifest lines, got %q", len(files), manifest)
+	}
+	sum := sha256.Sum256([]byte("#!/bin/sh\necho setup\n"))
+	if want := hex.EncodeToString(sum[:]) + "  scripts/setup.sh"; lines[4] != want {
+		t.Fatalf("expected %q, got %q", want, lines[4])
+	}
+}
+
+func TestBundleValidates(t *testing.T) {
+	dir := skillDir(t)
+	files, err := Collect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := filepath.Join(t.TempDir(), "my-skill.zip")
+	f, err := os.Create(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Zip(f, dir, files); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	result, err := validator.ValidateArchive(bundle, validator.Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || len(result.Warnings) != 0 {
+		t.Fatalf("expected a clean bundle, got %#v", result)
+	}
+}
+
+func TestIgnoreRules(t *testing.T) {
+	rules, err := parseIgnore(strings.NewReader("*.log\n!keep.log\ndist/\n/docs/*.md\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name    string
+		isDir   bool
+		ignored bool
+	}{
+		{"debug.log", false, true},
+		{"sub/debug.log", false, true},
+		{"keep.log", false, false},
+		{"dist", true, true},
+		{"dist", false, false},
+		{"docs/a.md", false, true},
+		{"sub/docs/a.md", false, false},
+		{"SKILL.md", false, false},
+	}
+	for _, tc := range cases {
+		if got := rules.ignored(tc.name, tc.isDir); got != tc.ignored {
+			t.Errorf("%s (dir=%v): expected ignored=%v, got %v", tc.name, tc.isDir, tc.ignored, got)
+		}
+	}
+
+	if _, err := parseIgnore(strings.NewReader("[\n")); err == nil {
+		t.Fatal("expected error for malformed pattern")
+	}
+}
@@ -13,6 +13,7 @@ type Frontmatter struct {
 	Body          string
 	LineCount     int
 	YAMLStartLine int
+	BodyStartLine int
 }
 
 var (
@@ -76,5 +77,6 @@ func ParseFrontmatter(r io.Reader) (Frontmatter, error) {
 		Body:          bodyText,
 		LineCount:     lineCount,
 		YAMLStartLine: 2,
+		BodyStartLine: end + 2,
 	}, nil
 }
@@ -23,6 +23,9 @@ func TestParseFrontmatterValid(t *testing.T) {
 	if fm.YAMLStartLine != 2 {
 		t.Fatalf("unexpected yaml start line: %d", fm.YAMLStartLine)
 	}
+	if fm.BodyStartLine != 4 {
+		t.Fatalf("unexpected body start line: %d", fm.BodyStartLine)
+	}
 }
 
 func TestParseFrontmatterBOM(t *testing.T) {
@@ -0,0 +1,115 @@
+package report
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// catalogDescriptionLength is the number of characters of a description
+// shown in the summary table of a catalog report.
+const catalogDescriptionLength = 120
+
+// catalog is the model of the Markdown and HTML reports: a summary of every
+// skill followed by a section per skill.
+type catalog struct {
+	Summary validator.Summary
+	Skills  []catalogSkill
+}
+
+type catalogSkill struct {
+	Anchor      string
+	Name        string
+	Description string
+	Path        string
+	Link        string
+	Valid       bool
+	Errors      int
+	Warnings    int
+	Findings    []catalogFinding
+}
+
+type catalogFinding struct {
+	Level    string
+	Code     string
+	Message  string
+	Location string
+	Link     string
+}
+
+// newCatalog builds the catalog of results. Paths and links are relative to
+// the working directory.
+func newCatalog(results []validator.Result) catalog {
+	base, _ := os.Getwd()
+	c := catalog{Summary: validator.Summarize(results), Skills: make([]catalogSkill, 0, len(results))}
+	for i, result := range results {
+		path := relativePath(base, result.Path)
+		skill := catalogSkill{
+			Anchor:      fmt.Sprintf("skill-%d", i+1),
+			Name:        result.Name,
+			Description: truncate(result.Description, catalogDescriptionLength),
+			Path:        filepath.ToSlash(path),
+			Link:        fileLink(path),
+			Valid:       result.Valid,
+			Errors:      len(result.Errors),
+			Warnings:    len(result.Warnings),
+		}
+		if skill.Name == "" {
+			skill.Name = filepath.Base(result.Path)
+		}
+		if info, err := os.Stat(result.Path); err == nil && info.IsDir() {
+			skill.Link = fileLink(filepath.Join(path, "SKILL.md"))
+		}
+		for _, finding := range allFindings(result) {
+			file, _ := diskLocation(base, result, finding)
+			location := filepath.ToSlash(findingPath(base, result, finding))
+			if finding.File != "" {
+				location = finding.File
+			}
+			if finding.Line > 0 {
+				location = fmt.Sprintf("%s:%d", location, finding.Line)
+			}
+			skill.Findings = append(skill.Findings, catalogFinding{
+				Level:    string(finding.Level),
+				Code:     finding.Code,
+				Message:  finding.Message,
+				Location: location,
+				Link:     fileLink(file),
+			})
+		}
+		c.Skills = append(c.Skills, skill)
+	}
+	return c
+}
+
+// fileLink returns a URL for path: relative paths are escaped segment by
+// segment and absolute paths become file URLs.
+func fileLink(path string) string {
+	segments := strings.Split(filepath.ToSlash(path), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	link := strings.Join(segments, "/")
+	if filepath.IsAbs(path) {
+		if !strings.HasPrefix(link, "/") {
+			link = "/" + link
+		}
+		return "file://" + link
+	}
+	return link
+}
+
+// truncate shortens s to at most n characters, ending it with an ellipsis
+// when it was cut.
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
@@ -0,0 +1,64 @@
+package report
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+const checkstyleVersion = "4.3"
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// RenderCheckstyle renders findings as Checkstyle XML, grouped by file in
+// the order the files are first reported. Paths are relative to the working
+// directory and the source of each error is sklint.<CODE>.
+func RenderCheckstyle(results []validator.Result) ([]byte, error) {
+	base, _ := os.Getwd()
+	report := checkstyleReport{Version: checkstyleVersion, Files: make([]checkstyleFile, 0)}
+	index := make(map[string]int)
+	for _, result := range results {
+		for _, finding := range allFindings(result) {
+			path, finding := diskLocation(base, result, finding)
+			path = filepath.ToSlash(path)
+			i, ok := index[path]
+			if !ok {
+				i = len(report.Files)
+				index[path] = i
+				report.Files = append(report.Files, checkstyleFile{Name: path})
+			}
+			report.Files[i].Errors = append(report.Files[i].Errors, checkstyleError{
+				Line:     finding.Line,
+				Column:   finding.Column,
+				Severity: string(finding.Level),
+				Message:  finding.Message,
+				Source:   toolName + "." + finding.Code,
+			})
+		}
+	}
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func TestRenderCheckstyle(t *testing.T) {
+	results := []validator.Result{{
+		Path:  testSkillPath(t, "skill"),
+		Valid: false,
+		Errors: []validator.Finding{
+			{Level: validator.LevelError, Code: "NAME_MISMATCH_DIRECTORY", Message: "bad", File: "SKILL.md", Line: 3, Column: 7},
+		},
+		Warnings: []validator.Finding{
+			{Level: validator.LevelWarning, Code: "SCRIPTS_DIR_EMPTY", Message: "empty", File: "scripts"},
+			{Level: validator.LevelWarning, Code: "REF_MISSING_FILE", Message: "missing", File: "SKILL.md", Line: 9},
+		},
+	}}
+	out, err := RenderCheckstyle(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(out), xml.Header) {
+		t.Fatalf("expected xml header, got %q", out)
+	}
+	var decoded checkstyleReport
+	if err := xml.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("unexpected xml error: %v", err)
+	}
+	if decoded.Version != "4.3" || len(decoded.Files) != 2 {
+		t.Fatalf("unexpected report: %#v", decoded)
+	}
+	skillMD := decoded.Files[0]
+	if skillMD.Name != "skills/skill/SKILL.md" || len(skillMD.Errors) != 2 {
+		t.Fatalf("expected SKILL.md findings grouped, got %#v", skillMD)
+	}
+	first := skillMD.Errors[0]
+	if first.Line != 3 || first.Column != 7 || first.Severity != "error" || first.Message != "bad" || first.Source != "sklint.NAME_MISMATCH_DIRECTORY" {
+		t.Fatalf("unexpected error: %#v", first)
+	}
+	if skillMD.Errors[1].Severity != "warning" || decoded.Files[1].Name != "skills/skill/scripts" || decoded.Files[1].Errors[0].Line != 0 {
+		t.Fatalf("unexpected warnings: %#v", decoded.Files)
+	}
+}
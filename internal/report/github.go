@@ -0,0 +1,130 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// RenderGitHub renders findings as GitHub Actions workflow commands, which
+// the runner turns into annotations on the pull request diff. File paths are
+// relative to $GITHUB_WORKSPACE, or to the working directory outside of
+// Actions.
+func RenderGitHub(results []validator.Result) []byte {
+	return renderGitHub(githubWorkspace(), results)
+}
+
+func renderGitHub(base string, results []validator.Result) []byte {
+	var b strings.Builder
+	for _, result := range results {
+		for _, finding := range allFindings(result) {
+			command := "warning"
+			if finding.Level == validator.LevelError {
+				command = "error"
+			}
+			path, finding := diskLocation(base, result, finding)
+			props := []string{"file=" + escapeGitHubProperty(filepath.ToSlash(path))}
+			if finding.Line > 0 {
+				props = append(props, fmt.Sprintf("line=%d", finding.Line))
+				if finding.Column > 0 {
+					props = append(props, fmt.Sprintf("col=%d", finding.Column))
+				}
+				if finding.EndLine > 0 {
+					props = append(props, fmt.Sprintf("endLine=%d", finding.EndLine))
+				}
+				if finding.EndColumn > 0 {
+					props = append(props, fmt.Sprintf("endColumn=%d", finding.EndColumn))
+				}
+			}
+			props = append(props, "title="+escapeGitHubProperty(finding.Code))
+			fmt.Fprintf(&b, "::%s %s::%s\n", command, strings.Join(props, ","), escapeGitHubData(finding.Message))
+		}
+	}
+	return []byte(b.String())
+}
+
+// RenderGitHubSummary renders a Markdown job summary of results.
+func RenderGitHubSummary(results []validator.Result) string {
+	return renderGitHubSummary(githubWorkspace(), results)
+}
+
+func renderGitHubSummary(base string, results []validator.Result) string {
+	summary := validator.Summarize(results)
+	var b strings.Builder
+	b.WriteString("## sklint\n\n")
+	fmt.Fprintf(&b, "%d skills (%d invalid), %d errors, %d warnings\n\n", summary.Skills, summary.Invalid, summary.Errors, summary.Warnings)
+	b.WriteString("| Skill | Status | Errors | Warnings |\n")
+	b.WriteString("| --- | --- | ---: | ---: |\n")
+	for _, result := range results {
+		status := ":white_check_mark: valid"
+		if !result.Valid {
+			status = ":x: invalid"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %d | %d |\n", filepath.ToSlash(relativePath(base, result.Path)), status, len(result.Errors), len(result.Warnings))
+	}
+
+	for _, result := range results {
+		findings := allFindings(result)
+		if len(findings) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### `%s`\n\n", filepath.ToSlash(relativePath(base, result.Path)))
+		for _, finding := range findings {
+			location := filepath.ToSlash(findingPath(base, result, finding))
+			if finding.Line > 0 {
+				location = fmt.Sprintf("%s:%d", location, finding.Line)
+			}
+			fmt.Fprintf(&b, "- **%s** `%s` `%s` %s\n", finding.Level, finding.Code, location, escapeMarkdown(finding.Message))
+		}
+	}
+	return b.String()
+}
+
+// WriteGitHubSummary appends the job summary of results to the file named by
+// $GITHUB_STEP_SUMMARY. It does nothing when the variable is unset.
+func WriteGitHubSummary(results []validator.Result) error {
+	file := os.Getenv("GITHUB_STEP_SUMMARY")
+	if file == "" {
+		return nil
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(RenderGitHubSummary(results)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func githubWorkspace() string {
+	if workspace := os.Getenv("GITHUB_WORKSPACE"); workspace != "" {
+		if abs, err := filepath.Abs(workspace); err == nil {
+			return abs
+		}
+	}
+	base, _ := os.Getwd()
+	return base
+}
+
+// escapeGitHubData escapes the message of a workflow command.
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeGitHubProperty escapes a workflow command property value.
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// escapeMarkdown escapes s for use as inline text, such as in a table cell.
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\", "|", "\\|", "*", "\\*", "_", "\\_", "`", "\\`",
+		"[", "\\[", "]", "\\]", "&", "&amp;", "<", "&lt;", ">", "&gt;", "\n", " ", "\r", "",
+	).Replace(s)
+}
@@ -0,0 +1,77 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func TestRenderGitHub(t *testing.T) {
+	results := []validator.Result{{
+		Path:  "/work/skills/demo",
+		Valid: false,
+		Errors: []validator.Finding{
+			{Level: validator.LevelError, Code: "NAME_MISMATCH_DIRECTORY", Message: "name: bad, 100%\nsecond", File: "SKILL.md", Line: 3, Column: 7, EndLine: 3, EndColumn: 12},
+		},
+		Warnings: []validator.Finding{
+			{Level: validator.LevelWarning, Code: "SCRIPTS_DIR_EMPTY", Message: "empty", File: "scripts"},
+		},
+	}}
+	out := string(renderGitHub("/work", results))
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 commands, got %q", out)
+	}
+	want := "::error file=skills/demo/SKILL.md,line=3,col=7,endLine=3,endColumn=12,title=NAME_MISMATCH_DIRECTORY::name: bad, 100%25%0Asecond"
+	if lines[0] != want {
+		t.Fatalf("unexpected error command:\n got %q\nwant %q", lines[0], want)
+	}
+	if lines[1] != "::warning file=skills/demo/scripts,title=SCRIPTS_DIR_EMPTY::empty" {
+		t.Fatalf("unexpected warning command: %q", lines[1])
+	}
+}
+
+func TestRenderGitHubSummary(t *testing.T) {
+	results := []validator.Result{
+		{Path: "/work/skills/a", Valid: true},
+		{
+			Path:  "/work/skills/b",
+			Valid: false,
+			Errors: []validator.Finding{
+				{Level: validator.LevelError, Code: "ERR", Message: "bad | worse", File: "SKILL.md", Line: 2},
+			},
+		},
+	}
+	out := renderGitHubSummary("/work", results)
+	if !strings.Contains(out, "2 skills (1 invalid), 1 errors, 0 warnings") {
+		t.Fatalf("expected totals, got %q", out)
+	}
+	if !strings.Contains(out, "| `skills/a` | :white_check_mark: valid | 0 | 0 |") ||
+		!strings.Contains(out, "| `skills/b` | :x: invalid | 1 | 0 |") {
+		t.Fatalf("expected table rows, got %q", out)
+	}
+	if !strings.Contains(out, "- **error** `ERR` `skills/b/SKILL.md:2` bad \\| worse") {
+		t.Fatalf("expected finding list, got %q", out)
+	}
+}
+
+func TestWriteGitHubSummary(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "summary.md")
+	if err := os.WriteFile(file, []byte("previous step\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_STEP_SUMMARY", file)
+	if err := WriteGitHubSummary([]validator.Result{{Path: "skill", Valid: true}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "previous step\n## sklint") {
+		t.Fatalf("expected summary appended, got %q", content)
+	}
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/sven1103-agent/sklint/internal/baseline"
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+// RenderGitLab renders findings as a GitLab Code Quality report. Paths are
+// relative to the working directory. Fingerprints hash the path and the
+// baseline fingerprint of the finding, which leaves out line numbers, so an
+// issue keeps its fingerprint when lines above it change; repeated identical
+// findings are told apart by their order.
+func RenderGitLab(results []validator.Result) ([]byte, error) {
+	base, _ := os.Getwd()
+	issues := make([]gitlabIssue, 0)
+	seen := make(map[string]int)
+	for _, result := range results {
+		for _, finding := range allFindings(result) {
+			path, finding := diskLocation(base, result, finding)
+			path = filepath.ToSlash(path)
+			key := path + "\x00" + baseline.Fingerprint(finding)
+			seen[key]++
+			sum := sha256.Sum256([]byte(key + "\x00" + strconv.Itoa(seen[key])))
+
+			line := finding.Line
+			if line == 0 {
+				line = 1
+			}
+			issues = append(issues, gitlabIssue{
+				Description: finding.Message,
+				CheckName:   finding.Code,
+				Fingerprint: hex.EncodeToString(sum[:16]),
+				Severity:    gitlabSeverity(finding.Level),
+				Location:    gitlabLocation{Path: path, Lines: gitlabLines{Begin: line}},
+			})
+		}
+	}
+	return json.MarshalIndent(issues, "", "  ")
+}
+
+func gitlabSeverity(level validator.FindingLevel) string {
+	if level == validator.LevelError {
+		return "major"
+	}
+	return "minor"
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func TestRenderGitLab(t *testing.T) {
+	results := []validator.Result{{
+		Path:  testSkillPath(t, "skill"),
+		Valid: false,
+		Errors: []validator.Finding{
+			{Level: validator.LevelError, Code: "NAME_MISMATCH_DIRECTORY", Message: "bad, see line 3", File: "SKILL.md", Line: 3, Column: 7},
+		},
+		Warnings: []validator.Finding{
+			{Level: validator.LevelWarning, Code: "REF_MISSING_FILE", Message: "missing", File: "SKILL.md", Line: 9},
+			{Level: validator.LevelWarning, Code: "REF_MISSING_FILE", Message: "missing", File: "SKILL.md", Line: 12},
+			{Level: validator.LevelWarning, Code: "SCRIPTS_DIR_EMPTY", Message: "empty", File: "scripts"},
+		},
+	}}
+	out, err := RenderGitLab(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var issues []gitlabIssue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		t.Fatalf("unexpected json error: %v", err)
+	}
+	if len(issues) != 4 {
+		t.Fatalf("expected 4 issues, got %d", len(issues))
+	}
+	first := issues[0]
+	if first.CheckName != "NAME_MISMATCH_DIRECTORY" || first.Severity != "major" || first.Description != "bad, see line 3" ||
+		first.Location.Path != "skills/skill/SKILL.md" || first.Location.Lines.Begin != 3 {
+		t.Fatalf("unexpected first issue: %#v", first)
+	}
+	if issues[1].Severity != "minor" || issues[3].Location.Lines.Begin != 1 {
+		t.Fatalf("unexpected warning issues: %#v", issues)
+	}
+	fingerprints := make(map[string]bool)
+	for _, issue := range issues {
+		if fingerprints[issue.Fingerprint] {
+			t.Fatalf("duplicate fingerprint %s", issue.Fingerprint)
+		}
+		fingerprints[issue.Fingerprint] = true
+	}
+
+	results[0].Errors[0].Line = 4
+	results[0].Errors[0].Message = "bad, see line 4"
+	again, err := RenderGitLab(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var moved []gitlabIssue
+	if err := json.Unmarshal(again, &moved); err != nil {
+		t.Fatalf("unexpected json error: %v", err)
+	}
+	for i := range issues {
+		if moved[i].Fingerprint != issues[i].Fingerprint {
+			t.Fatalf("fingerprint of issue %d changed when its line moved", i)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"html/template"
+	"strings"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// htmlTemplate is a single page with embedded CSS, so the report can be
+// shared as one file without external assets.
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"status": catalogStatus,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>sklint report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; margin: 2rem auto; max-width: 72rem; padding: 0 1rem; line-height: 1.5; }
+h1 { font-size: 1.75rem; margin-bottom: 0.25rem; }
+h2 { font-size: 1.25rem; margin-top: 2.5rem; border-bottom: 1px solid #d0d7de; padding-bottom: 0.25rem; }
+table { border-collapse: collapse; width: 100%; margin: 1rem 0; }
+th, td { border: 1px solid #d0d7de; padding: 0.375rem 0.75rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+td.count { text-align: right; font-variant-numeric: tabular-nums; }
+code { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 0.875em; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+.summary { color: #59636e; }
+.badge { display: inline-block; border-radius: 1em; padding: 0 0.6em; font-size: 0.8125rem; font-weight: 600; }
+.valid { background: #dafbe1; color: #116329; }
+.invalid, .error { background: #ffebe9; color: #a40e26; }
+.warning { background: #fff8c5; color: #7d4e00; }
+.empty { color: #59636e; font-style: italic; }
+</style>
+</head>
+<body>
+<h1>sklint report</h1>
+<p class="summary">{{.Summary.Skills}} skills ({{.Summary.Invalid}} invalid), {{.Summary.Errors}} errors, {{.Summary.Warnings}} warnings</p>
+<table>
+<thead><tr><th>Skill</th><th>Description</th><th>Errors</th><th>Warnings</th><th>Status</th></tr></thead>
+<tbody>
+{{- range .Skills}}
+<tr><td><a href="#{{.Anchor}}">{{.Name}}</a></td><td>{{.Description}}</td><td class="count">{{.Errors}}</td><td class="count">{{.Warnings}}</td><td><span class="badge {{status .Valid}}">{{status .Valid}}</span></td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- range .Skills}}
+<section id="{{.Anchor}}">
+<h2>{{.Name}}</h2>
+<p><a href="{{.Link}}"><code>{{.Path}}</code></a> <span class="badge {{status .Valid}}">{{status .Valid}}</span> {{.Errors}} errors, {{.Warnings}} warnings</p>
+{{- if .Findings}}
+<table>
+<thead><tr><th>Level</th><th>Code</th><th>Location</th><th>Message</th></tr></thead>
+<tbody>
+{{- range .Findings}}
+<tr><td><span class="badge {{.Level}}">{{.Level}}</span></td><td><code>{{.Code}}</code></td><td><a href="{{.Link}}"><code>{{.Location}}</code></a></td><td>{{.Message}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p class="empty">No findings.</p>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// RenderHTML renders the report of RenderMarkdown as a single HTML page
+// with embedded CSS and no external assets.
+func RenderHTML(results []validator.Result) ([]byte, error) {
+	var b strings.Builder
+	if err := htmlTemplate.Execute(&b, newCatalog(results)); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
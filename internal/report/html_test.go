@@ -0,0 +1,31 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTML(t *testing.T) {
+	out, err := RenderHTML(catalogResults(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	page := string(out)
+	if !strings.HasPrefix(page, "<!DOCTYPE html>") || !strings.Contains(page, "<style>") {
+		t.Fatalf("expected a page with embedded css, got %q", page)
+	}
+	for _, external := range []string{"<link", "<script", "src="} {
+		if strings.Contains(page, external) {
+			t.Fatalf("expected no external assets, found %q", external)
+		}
+	}
+	if !strings.Contains(page, `<a href="#skill-1">alpha</a></td><td>Does | things</td>`) {
+		t.Fatalf("expected summary row, got %q", page)
+	}
+	if !strings.Contains(page, `<section id="skill-2">`) || !strings.Contains(page, "name &lt;missing&gt;") {
+		t.Fatalf("expected escaped finding section, got %q", page)
+	}
+	if !strings.Contains(page, `<span class="badge warning">warning</span>`) {
+		t.Fatalf("expected warning badge, got %q", page)
+	}
+}
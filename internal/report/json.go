@@ -9,3 +9,15 @@ import (
 func RenderJSON(result validator.Result) ([]byte, error) {
 	return json.MarshalIndent(result, "", "  ")
 }
+
+type jsonReport struct {
+	Results []validator.Result `json:"results"`
+	Summary validator.Summary  `json:"summary"`
+}
+
+func RenderJSONReport(results []validator.Result) ([]byte, error) {
+	if results == nil {
+		results = []validator.Result{}
+	}
+	return json.MarshalIndent(jsonReport{Results: results, Summary: validator.Summarize(results)}, "", "  ")
+}
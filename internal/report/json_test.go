@@ -24,3 +24,24 @@ func TestRenderJSON(t *testing.T) {
 		t.Fatalf("unexpected decoded result: %#v", decoded)
 	}
 }
+
+func TestRenderJSONReport(t *testing.T) {
+	results := []validator.Result{
+		{Path: "/tmp/a", Valid: true},
+		{Path: "/tmp/b", Valid: false},
+	}
+	out, err := RenderJSONReport(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded struct {
+		Results []validator.Result `json:"results"`
+		Summary validator.Summary  `json:"summary"`
+	}
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("unexpected json error: %v", err)
+	}
+	if len(decoded.Results) != 2 || decoded.Summary.Skills != 2 || decoded.Summary.Invalid != 1 {
+		t.Fatalf("unexpected decoded report: %#v", decoded)
+	}
+}
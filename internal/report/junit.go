@@ -0,0 +1,130 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// RenderJUnit renders results as JUnit XML with a test suite per skill and a
+// test case per rule. Errors fail their rule's test case and rules disabled
+// for the skill are skipped. Warnings are kept in the test case's output,
+// unless they alone make the skill invalid, as in strict mode; then they fail
+// it too, so the report agrees with the exit status.
+func RenderJUnit(results []validator.Result) ([]byte, error) {
+	codes := validator.Codes()
+	base, _ := os.Getwd()
+	report := junitTestSuites{Name: toolName, Suites: make([]junitTestSuite, 0, len(results))}
+	for _, result := range results {
+		disabled := make(map[string]bool, len(result.Disabled))
+		for _, code := range result.Disabled {
+			disabled[code] = true
+		}
+		warningsFail := !result.Valid && len(result.Errors) == 0
+		byCode := make(map[string][]validator.Finding)
+		for _, finding := range allFindings(result) {
+			byCode[finding.Code] = append(byCode[finding.Code], finding)
+		}
+
+		suite := junitTestSuite{
+			Name:  filepath.ToSlash(relativePath(base, result.Path)),
+			Tests: len(codes),
+			Cases: make([]junitTestCase, 0, len(codes)),
+		}
+		for _, info := range codes {
+			tc := junitTestCase{Name: info.Code, ClassName: toolName + "." + info.Category}
+			var errorMessage, warningMessage string
+			var errors, warnings []string
+			for _, finding := range byCode[info.Code] {
+				line := junitLocation(base, result, finding) + ": " + finding.Message
+				if finding.Level == validator.LevelError {
+					if errorMessage == "" {
+						errorMessage = finding.Message
+					}
+					errors = append(errors, line)
+				} else {
+					if warningMessage == "" {
+						warningMessage = finding.Message
+					}
+					warnings = append(warnings, line)
+				}
+			}
+			switch {
+			case len(errors) > 0:
+				tc.Failure = &junitFailure{Message: errorMessage, Type: string(validator.LevelError), Text: strings.Join(errors, "\n")}
+				suite.Failures++
+			case len(warnings) > 0 && warningsFail:
+				tc.Failure = &junitFailure{Message: warningMessage, Type: string(validator.LevelWarning), Text: strings.Join(warnings, "\n")}
+				warnings = nil
+				suite.Failures++
+			case disabled[info.Code]:
+				tc.Skipped = &junitSkipped{Message: "rule is disabled"}
+				suite.Skipped++
+			}
+			if len(warnings) > 0 {
+				tc.SystemOut = strings.Join(warnings, "\n")
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+	}
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+func junitLocation(base string, result validator.Result, finding validator.Finding) string {
+	location := filepath.ToSlash(findingPath(base, result, finding))
+	if finding.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, finding.Line)
+		if finding.Column > 0 {
+			location = fmt.Sprintf("%s:%d", location, finding.Column)
+		}
+	}
+	return location
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func TestRenderJUnit(t *testing.T) {
+	results := []validator.Result{
+		{Path: testSkillPath(t, "a"), Valid: true, Disabled: []string{"YAML_ANCHOR"}},
+		{
+			Path:  testSkillPath(t, "b"),
+			Valid: false,
+			Errors: []validator.Finding{
+				{Level: validator.LevelError, Code: "NAME_MISMATCH_DIRECTORY", Message: "bad", File: "SKILL.md", Line: 3, Column: 7},
+			},
+			Warnings: []validator.Finding{
+				{Level: validator.LevelWarning, Code: "SCRIPTS_DIR_EMPTY", Message: "empty", File: "scripts"},
+			},
+		},
+	}
+	out, err := RenderJUnit(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(out), xml.Header) {
+		t.Fatalf("expected xml header, got %q", out)
+	}
+
+	var decoded junitTestSuites
+	if err := xml.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("unexpected xml error: %v", err)
+	}
+	codes := len(validator.Codes())
+	if len(decoded.Suites) != 2 || decoded.Tests != 2*codes || decoded.Failures != 1 || decoded.Skipped != 1 {
+		t.Fatalf("unexpected totals: tests=%d failures=%d skipped=%d suites=%d", decoded.Tests, decoded.Failures, decoded.Skipped, len(decoded.Suites))
+	}
+
+	cases := func(suite junitTestSuite) map[string]junitTestCase {
+		byName := make(map[string]junitTestCase, len(suite.Cases))
+		for _, tc := range suite.Cases {
+			byName[tc.Name] = tc
+		}
+		return byName
+	}
+	if decoded.Suites[0].Name != "skills/a" || decoded.Suites[1].Name != "skills/b" {
+		t.Fatalf("unexpected suite names %q, %q", decoded.Suites[0].Name, decoded.Suites[1].Name)
+	}
+	a := cases(decoded.Suites[0])
+	if len(a) != codes || a["YAML_ANCHOR"].Skipped == nil || a["NAME_MISSING"].Failure != nil || a["NAME_MISSING"].Skipped != nil {
+		t.Fatalf("unexpected cases for skills/a: %#v", decoded.Suites[0])
+	}
+
+	b := cases(decoded.Suites[1])
+	failure := b["NAME_MISMATCH_DIRECTORY"].Failure
+	if failure == nil || failure.Message != "bad" || failure.Type != "error" || failure.Text != "skills/b/SKILL.md:3:7: bad" {
+		t.Fatalf("unexpected failure: %#v", failure)
+	}
+	if b["NAME_MISMATCH_DIRECTORY"].ClassName != "sklint.name" {
+		t.Fatalf("unexpected classname: %q", b["NAME_MISMATCH_DIRECTORY"].ClassName)
+	}
+	warning := b["SCRIPTS_DIR_EMPTY"]
+	if warning.Failure != nil || warning.SystemOut != "skills/b/scripts: empty" {
+		t.Fatalf("expected warning in system-out, got %#v", warning)
+	}
+}
+
+func TestRenderJUnitStrictWarnings(t *testing.T) {
+	results := []validator.Result{{
+		Path:  "/tmp/strict",
+		Valid: false,
+		Warnings: []validator.Finding{
+			{Level: validator.LevelWarning, Code: "SCRIPTS_DIR_EMPTY", Message: "empty", File: "scripts"},
+		},
+	}}
+	out, err := RenderJUnit(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded junitTestSuites
+	if err := xml.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("unexpected xml error: %v", err)
+	}
+	if decoded.Failures != 1 {
+		t.Fatalf("expected the warning to fail the invalid skill, got %d failures", decoded.Failures)
+	}
+	for _, tc := range decoded.Suites[0].Cases {
+		if tc.Name != "SCRIPTS_DIR_EMPTY" {
+			continue
+		}
+		if tc.Failure == nil || tc.Failure.Type != "warning" || tc.Failure.Message != "empty" || tc.SystemOut != "" {
+			t.Fatalf("unexpected test case: %#v", tc)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// RenderMarkdown renders results as a Markdown report: a summary table of
+// every skill with its name, description, finding counts and status,
+// followed by a section listing the findings of each skill. File links are
+// relative to the working directory.
+func RenderMarkdown(results []validator.Result) string {
+	c := newCatalog(results)
+	var b strings.Builder
+	b.WriteString("# sklint report\n\n")
+	fmt.Fprintf(&b, "%d skills (%d invalid), %d errors, %d warnings\n\n", c.Summary.Skills, c.Summary.Invalid, c.Summary.Errors, c.Summary.Warnings)
+	b.WriteString("| Skill | Description | Errors | Warnings | Status |\n")
+	b.WriteString("| --- | --- | ---: | ---: | --- |\n")
+	for _, skill := range c.Skills {
+		fmt.Fprintf(&b, "| [%s](#%s) | %s | %d | %d | %s |\n",
+			escapeMarkdown(skill.Name), skill.Anchor, escapeMarkdown(skill.Description), skill.Errors, skill.Warnings, catalogStatus(skill.Valid))
+	}
+
+	for _, skill := range c.Skills {
+		fmt.Fprintf(&b, "\n<a id=\"%s\"></a>\n\n## %s\n\n", skill.Anchor, escapeMarkdown(skill.Name))
+		fmt.Fprintf(&b, "[`%s`](%s) - %s, %d errors, %d warnings\n\n", skill.Path, skill.Link, catalogStatus(skill.Valid), skill.Errors, skill.Warnings)
+		if len(skill.Findings) == 0 {
+			b.WriteString("No findings.\n")
+			continue
+		}
+		b.WriteString("| Level | Code | Location | Message |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, finding := range skill.Findings {
+			fmt.Fprintf(&b, "| %s | `%s` | [%s](%s) | %s |\n",
+				finding.Level, finding.Code, escapeMarkdown(finding.Location), finding.Link, escapeMarkdown(finding.Message))
+		}
+	}
+	return b.String()
+}
+
+func catalogStatus(valid bool) string {
+	if valid {
+		return "valid"
+	}
+	return "invalid"
+}
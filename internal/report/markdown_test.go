@@ -0,0 +1,64 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func catalogResults(t *testing.T) []validator.Result {
+	return []validator.Result{
+		{Path: testSkillPath(t, "a"), Valid: true, Name: "alpha", Description: "Does | things"},
+		{
+			Path:  testSkillPath(t, "b"),
+			Valid: false,
+			Errors: []validator.Finding{
+				{Level: validator.LevelError, Code: "NAME_MISSING", Message: "name <missing>", File: "SKILL.md", Line: 1},
+			},
+			Warnings: []validator.Finding{
+				{Level: validator.LevelWarning, Code: "SCRIPTS_DIR_EMPTY", Message: "empty", File: "scripts"},
+			},
+		},
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	out := RenderMarkdown(catalogResults(t))
+	if !strings.Contains(out, "2 skills (1 invalid), 1 errors, 1 warnings") {
+		t.Fatalf("expected totals, got %q", out)
+	}
+	if !strings.Contains(out, "| [alpha](#skill-1) | Does \\| things | 0 | 0 | valid |") {
+		t.Fatalf("expected summary row with name and description, got %q", out)
+	}
+	if !strings.Contains(out, "| [b](#skill-2) |  | 1 | 1 | invalid |") {
+		t.Fatalf("expected directory name when the skill has no name, got %q", out)
+	}
+	if !strings.Contains(out, "<a id=\"skill-2\"></a>\n\n## b\n") {
+		t.Fatalf("expected anchored section, got %q", out)
+	}
+	if !strings.Contains(out, "| error | `NAME_MISSING` | [SKILL.md:1](skills/b/SKILL.md) | name &lt;missing&gt; |") {
+		t.Fatalf("expected linked finding, got %q", out)
+	}
+	if !strings.Contains(out, "No findings.") {
+		t.Fatalf("expected empty section for alpha, got %q", out)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("short\n text", 20); got != "short text" {
+		t.Fatalf("unexpected result: %q", got)
+	}
+	if got := truncate("abcdefgh", 5); got != "abcd…" {
+		t.Fatalf("unexpected result: %q", got)
+	}
+}
+
+func TestFileLink(t *testing.T) {
+	if got := fileLink("skills/my skill/SKILL.md"); got != "skills/my%20skill/SKILL.md" {
+		t.Fatalf("unexpected relative link: %q", got)
+	}
+	if got := fileLink("/tmp/SKILL.md"); got != "file:///tmp/SKILL.md" {
+		t.Fatalf("unexpected absolute link: %q", got)
+	}
+}
@@ -0,0 +1,172 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// ANSI escape sequences used by RenderPretty.
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiDim    = "\033[2m"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+	ansiBlue   = "\033[34m"
+	ansiGreen  = "\033[32m"
+)
+
+// yamlContextLines is how many lines around an invalid YAML finding are
+// shown, since the parser often reports the line after the actual mistake.
+const yamlContextLines = 2
+
+// RenderPretty renders results for a terminal: findings are grouped by file
+// and those located in a file are followed by the source line with a caret
+// under the reported columns. Paths are relative to the working directory.
+// With color set, levels, gutters and the summary are highlighted with ANSI
+// escape sequences.
+func RenderPretty(results []validator.Result, color bool) string {
+	base, _ := os.Getwd()
+	p := prettyPrinter{color: color, sources: make(map[string][]string)}
+	var b strings.Builder
+	for _, result := range results {
+		files := make([]string, 0)
+		byFile := make(map[string][]validator.Finding)
+		for _, finding := range allFindings(result) {
+			path := findingPath(base, result, finding)
+			if _, ok := byFile[path]; !ok {
+				files = append(files, path)
+			}
+			byFile[path] = append(byFile[path], finding)
+		}
+		for _, file := range files {
+			b.WriteString(p.style(ansiBold, filepath.ToSlash(file)))
+			b.WriteString("\n")
+			for _, finding := range byFile[file] {
+				source := ""
+				if finding.File != "" && !result.Archive {
+					source = filepath.Join(result.Path, filepath.FromSlash(finding.File))
+				}
+				p.writeFinding(&b, source, finding)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	summary := validator.Summarize(results)
+	status := p.style(ansiBold+ansiGreen, "VALID")
+	if summary.Invalid > 0 {
+		status = p.style(ansiBold+ansiRed, "INVALID")
+	}
+	b.WriteString(fmt.Sprintf("%d skills (%d invalid), %s, %s - %s\n",
+		summary.Skills, summary.Invalid,
+		p.count(summary.Errors, "errors", ansiRed), p.count(summary.Warnings, "warnings", ansiYellow), status))
+	return b.String()
+}
+
+type prettyPrinter struct {
+	color   bool
+	sources map[string][]string
+}
+
+func (p prettyPrinter) style(code, s string) string {
+	if !p.color {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+func (p prettyPrinter) count(n int, noun, code string) string {
+	s := fmt.Sprintf("%d %s", n, noun)
+	if n == 0 {
+		return s
+	}
+	return p.style(code, s)
+}
+
+// writeFinding writes finding followed by an excerpt of file, the source it
+// is located in, unless file is empty.
+func (p prettyPrinter) writeFinding(b *strings.Builder, file string, finding validator.Finding) {
+	levelColor := ansiYellow
+	if finding.Level == validator.LevelError {
+		levelColor = ansiRed
+	}
+	location := ""
+	if finding.Line > 0 {
+		location = fmt.Sprintf("%d", finding.Line)
+		if finding.Column > 0 {
+			location = fmt.Sprintf("%s:%d", location, finding.Column)
+		}
+		location = " " + p.style(ansiDim, location)
+	}
+	fmt.Fprintf(b, "  %s%s %s\n", p.style(ansiBold+levelColor, fmt.Sprintf("%s[%s]", finding.Level, finding.Code)), location, finding.Message)
+
+	if finding.Line < 1 || file == "" {
+		return
+	}
+	lines := p.source(file)
+	if finding.Line > len(lines) {
+		return
+	}
+	first, last := finding.Line, finding.Line
+	if finding.Code == "FRONTMATTER_INVALID_YAML" {
+		first = max(1, finding.Line-yamlContextLines)
+		last = min(len(lines), finding.Line+yamlContextLines)
+	}
+	width := len(fmt.Sprint(last))
+	gutter := func(label string) string {
+		return p.style(ansiBlue, fmt.Sprintf("%*s |", width, label))
+	}
+	fmt.Fprintf(b, "    %s\n", gutter(""))
+	for n := first; n <= last; n++ {
+		fmt.Fprintf(b, "    %s %s\n", gutter(fmt.Sprint(n)), lines[n-1])
+		if n == finding.Line && finding.Column > 0 {
+			fmt.Fprintf(b, "    %s %s\n", gutter(""), p.style(ansiBold+levelColor, caret(lines[n-1], finding)))
+		}
+	}
+}
+
+// source returns the lines of file, or nil when it cannot be read.
+func (p prettyPrinter) source(file string) []string {
+	if lines, ok := p.sources[file]; ok {
+		return lines
+	}
+	content, err := os.ReadFile(file)
+	var lines []string
+	if err == nil {
+		text := strings.TrimPrefix(string(content), "\uFEFF")
+		lines = strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	}
+	p.sources[file] = lines
+	return lines
+}
+
+// caret returns the marker placed under the finding's columns of line.
+// Tabs before the column are kept so the marker lines up with the source.
+func caret(line string, finding validator.Finding) string {
+	var pad strings.Builder
+	col := 1
+	for _, r := range line {
+		if col >= finding.Column {
+			break
+		}
+		if r == '\t' {
+			pad.WriteRune('\t')
+		} else {
+			pad.WriteRune(' ')
+		}
+		col++
+	}
+	length := 1
+	if finding.EndLine == finding.Line && finding.EndColumn > finding.Column {
+		length = finding.EndColumn - finding.Column
+	} else if finding.EndLine > finding.Line {
+		length = max(1, utf8.RuneCountInString(line)-finding.Column+1)
+	}
+	return pad.String() + strings.Repeat("^", length)
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+func TestRenderPretty(t *testing.T) {
+	dir := t.TempDir()
+	content := "---\nname: Other\ndescription: demo\n---\nBody\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results := []validator.Result{{
+		Path:  dir,
+		Valid: false,
+		Errors: []validator.Finding{
+			{Level: validator.LevelError, Code: "NAME_MISMATCH_DIRECTORY", Message: "bad", File: "SKILL.md", Line: 2, Column: 7, EndLine: 2, EndColumn: 12},
+		},
+		Warnings: []validator.Finding{
+			{Level: validator.LevelWarning, Code: "SCRIPTS_DIR_EMPTY", Message: "empty", File: "scripts"},
+		},
+	}}
+
+	out := RenderPretty(results, false)
+	if strings.Contains(out, "\033[") {
+		t.Fatalf("expected no escape sequences, got %q", out)
+	}
+	if !strings.Contains(out, "SKILL.md\n  error[NAME_MISMATCH_DIRECTORY] 2:7 bad\n") {
+		t.Fatalf("expected finding grouped under SKILL.md, got %q", out)
+	}
+	if !strings.Contains(out, "    2 | name: Other\n      |       ^^^^^\n") {
+		t.Fatalf("expected source excerpt with caret, got %q", out)
+	}
+	if !strings.Contains(out, "scripts\n  warning[SCRIPTS_DIR_EMPTY] empty\n") {
+		t.Fatalf("expected warning grouped under scripts, got %q", out)
+	}
+	if !strings.HasSuffix(out, "1 skills (1 invalid), 1 errors, 1 warnings - INVALID\n") {
+		t.Fatalf("unexpected summary: %q", out)
+	}
+
+	colored := RenderPretty(results, true)
+	if !strings.Contains(colored, ansiRed+"error[NAME_MISMATCH_DIRECTORY]") || !strings.Contains(colored, ansiYellow+"warning[SCRIPTS_DIR_EMPTY]") {
+		t.Fatalf("expected colored levels, got %q", colored)
+	}
+}
+
+func TestRenderPrettyInvalidYAMLContext(t *testing.T) {
+	dir := t.TempDir()
+	content := "---\nname: [\ndescription: Broken\nlicense: MIT\n---\nBody\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results := []validator.Result{{
+		Path: dir,
+		Errors: []validator.Finding{
+			{Level: validator.LevelError, Code: "FRONTMATTER_INVALID_YAML", Message: "invalid", File: "SKILL.md", Line: 3, Column: 1, EndLine: 3, EndColumn: 21},
+		},
+	}}
+	out := RenderPretty(results, false)
+	want := "    1 | ---\n    2 | name: [\n    3 | description: Broken\n      | ^^^^^^^^^^^^^^^^^^^^\n    4 | license: MIT\n    5 | ---\n"
+	if !strings.Contains(out, want) {
+		t.Fatalf("expected surrounding lines, got %q", out)
+	}
+}
+
+func TestCaretKeepsTabs(t *testing.T) {
+	finding := validator.Finding{Line: 1, Column: 3, EndLine: 1, EndColumn: 5}
+	if got := caret("\tx yz", finding); got != "\t ^^" {
+		t.Fatalf("unexpected caret: %q", got)
+	}
+}
+
+func TestRenderPrettyArchiveHasNoExcerpt(t *testing.T) {
+	// A directory named like the archive must not be mistaken for it.
+	archive := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := os.MkdirAll(filepath.Join(archive, "my-skill"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(archive, "my-skill", "SKILL.md"), []byte("---\nname: Other\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results := []validator.Result{{
+		Path:    archive,
+		Archive: true,
+		Errors: []validator.Finding{
+			{Level: validator.LevelError, Code: "NAME_MISMATCH_DIRECTORY", Message: "bad", File: "my-skill/SKILL.md", Line: 2, Column: 7},
+		},
+	}}
+	out := RenderPretty(results, false)
+	if !strings.Contains(out, "error[NAME_MISMATCH_DIRECTORY] 2:7 bad\n") || strings.Contains(out, "name: Other") {
+		t.Fatalf("expected the finding without a source excerpt, got %q", out)
+	}
+}
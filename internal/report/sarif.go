@@ -0,0 +1,209 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "sklint"
+	toolURI      = "https://github.com/sven1103-agent/sklint"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex *int            `json:"ruleIndex,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// RenderSARIF renders results as a single SARIF 2.1.0 run. Artifact URIs are
+// relative to the working directory so code-scanning tools can map them onto
+// the checked-out repository.
+func RenderSARIF(results []validator.Result) ([]byte, error) {
+	codes := validator.Codes()
+	rules := make([]sarifRule, 0, len(codes))
+	ruleIndex := make(map[string]int, len(codes))
+	for i, info := range codes {
+		rules = append(rules, sarifRule{
+			ID:                   info.Code,
+			ShortDescription:     sarifMessage{Text: info.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(info.Level)},
+		})
+		ruleIndex[info.Code] = i
+	}
+
+	base, _ := os.Getwd()
+	sarifResults := make([]sarifResult, 0)
+	for _, result := range results {
+		for _, finding := range allFindings(result) {
+			path, finding := diskLocation(base, result, finding)
+			sr := sarifResult{
+				RuleID:  finding.Code,
+				Level:   sarifLevel(finding.Level),
+				Message: sarifMessage{Text: finding.Message},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: artifactURI(path)},
+					},
+				}},
+			}
+			if index, ok := ruleIndex[finding.Code]; ok {
+				sr.RuleIndex = &index
+			}
+			if finding.Line > 0 {
+				sr.Locations[0].PhysicalLocation.Region = &sarifRegion{
+					StartLine:   finding.Line,
+					StartColumn: finding.Column,
+					EndLine:     finding.EndLine,
+					EndColumn:   finding.EndColumn,
+				}
+			}
+			sarifResults = append(sarifResults, sr)
+		}
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           toolName,
+				InformationURI: toolURI,
+				Rules:          rules,
+			}},
+			Results: sarifResults,
+		}},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
+
+func sarifLevel(level validator.FindingLevel) string {
+	if level == validator.LevelError {
+		return "error"
+	}
+	return "warning"
+}
+
+func allFindings(result validator.Result) []validator.Finding {
+	findings := make([]validator.Finding, 0, len(result.Errors)+len(result.Warnings))
+	findings = append(findings, result.Errors...)
+	return append(findings, result.Warnings...)
+}
+
+// findingPath joins the skill path with the finding's file and makes it
+// relative to base when possible.
+func findingPath(base string, result validator.Result, finding validator.Finding) string {
+	path := result.Path
+	if finding.File != "" {
+		path = filepath.Join(result.Path, filepath.FromSlash(finding.File))
+	}
+	return relativePath(base, path)
+}
+
+// relativePath makes path relative to base when possible.
+func relativePath(base, path string) string {
+	if base != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			if rel, err := filepath.Rel(base, abs); err == nil {
+				path = rel
+			}
+		}
+	}
+	return path
+}
+
+// diskLocation returns the file on disk that formats pointing into files
+// report a finding against, and the finding as they should show it. Files
+// inside an archive do not exist on disk, so their findings point at the
+// archive itself and carry the entry and position in the message.
+func diskLocation(base string, result validator.Result, finding validator.Finding) (string, validator.Finding) {
+	if !result.Archive || finding.File == "" {
+		return findingPath(base, result, finding), finding
+	}
+	location := finding.File
+	if finding.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, finding.Line)
+		if finding.Column > 0 {
+			location = fmt.Sprintf("%s:%d", location, finding.Column)
+		}
+	}
+	finding.Message = location + ": " + finding.Message
+	finding.File = ""
+	finding.Line, finding.Column, finding.EndLine, finding.EndColumn = 0, 0, 0, 0
+	return relativePath(base, result.Path), finding
+}
+
+func artifactURI(path string) string {
+	if filepath.IsAbs(path) {
+		uri := filepath.ToSlash(path)
+		if !strings.HasPrefix(uri, "/") {
+			uri = "/" + uri
+		}
+		return "file://" + uri
+	}
+	return filepath.ToSlash(path)
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sven1103-agent/sklint/pkg/validator"
+)
+
+// testSkillPath returns an absolute path below the working directory, so
+// that renderers relativize it to "skills/<name>" wherever the tests run.
+func testSkillPath(t *testing.T, name string) string {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(wd, "skills", name)
+}
+
+func TestRenderSARIF(t *testing.T) {
+	results := []validator.Result{{
+		Path:  testSkillPath(t, "skill"),
+		Valid: false,
+		Errors: []validator.Finding{
+			{Level: validator.LevelError, Code: "NAME_MISMATCH_DIRECTORY", Message: "bad", File: "SKILL.md", Line: 3, Column: 7, EndLine: 3, EndColumn: 12},
+		},
+		Warnings: []validator.Finding{
+			{Level: validator.LevelWarning, Code: "SCRIPTS_DIR_EMPTY", Message: "empty", File: "scripts"},
+		},
+	}}
+	out, err := RenderSARIF(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded sarifLog
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("unexpected json error: %v", err)
+	}
+	if decoded.Version != "2.1.0" || len(decoded.Runs) != 1 {
+		t.Fatalf("unexpected sarif log: %#v", decoded)
+	}
+	run := decoded.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(validator.Codes()) {
+		t.Fatalf("expected every code as a rule, got %d", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.RuleID != "NAME_MISMATCH_DIRECTORY" || first.Level != "error" {
+		t.Fatalf("unexpected first result: %#v", first)
+	}
+	if first.RuleIndex == nil || run.Tool.Driver.Rules[*first.RuleIndex].ID != first.RuleID {
+		t.Fatalf("rule index does not point at rule: %#v", first)
+	}
+	location := first.Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != "skills/skill/SKILL.md" {
+		t.Fatalf("unexpected artifact uri: %s", location.ArtifactLocation.URI)
+	}
+	if location.Region == nil || location.Region.StartLine != 3 || location.Region.StartColumn != 7 ||
+		location.Region.EndLine != 3 || location.Region.EndColumn != 12 {
+		t.Fatalf("unexpected region: %#v", location.Region)
+	}
+
+	second := run.Results[1]
+	if second.Level != "warning" || second.Locations[0].PhysicalLocation.Region != nil {
+		t.Fatalf("unexpected second result: %#v", second)
+	}
+}
+
+func TestArchiveFindingsPointAtArchive(t *testing.T) {
+	results := []validator.Result{{
+		Path:    testSkillPath(t, "bundle.zip"),
+		Archive: true,
+		Errors: []validator.Finding{
+			{Level: validator.LevelError, Code: "NAME_MISMATCH_DIRECTORY", Message: "bad", File: "my-skill/SKILL.md", Line: 2, Column: 7, EndLine: 2, EndColumn: 12},
+		},
+	}}
+
+	out, err := RenderSARIF(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded sarifLog
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("unexpected json error: %v", err)
+	}
+	sr := decoded.Runs[0].Results[0]
+	location := sr.Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != "skills/bundle.zip" || location.Region != nil || sr.Message.Text != "my-skill/SKILL.md:2:7: bad" {
+		t.Fatalf("expected the finding at the archive, got %#v", sr)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if github := string(renderGitHub(wd, results)); github != "::error file=skills/bundle.zip,title=NAME_MISMATCH_DIRECTORY::my-skill/SKILL.md:2:7: bad\n" {
+		t.Fatalf("expected the annotation at the archive, got %q", github)
+	}
+	checkstyle, err := RenderCheckstyle(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(checkstyle), `<file name="skills/bundle.zip">`) || strings.Contains(string(checkstyle), `line=`) {
+		t.Fatalf("expected the checkstyle error at the archive, got %s", checkstyle)
+	}
+}
@@ -42,8 +42,34 @@ func formatFinding(f validator.Finding) string {
 		location = f.File
 		if f.Line > 0 {
 			location = fmt.Sprintf("%s:%d", f.File, f.Line)
+			if f.Column > 0 {
+				location = fmt.Sprintf("%s:%d", location, f.Column)
+			}
 		}
 		location = " " + location
 	}
 	return fmt.Sprintf("- %s%s %s\n", f.Code, location, f.Message)
 }
+
+func RenderTextReport(results []validator.Result) string {
+	var b strings.Builder
+	for i, result := range results {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(result.Path)
+		b.WriteString("\n")
+		b.WriteString(RenderText(result))
+	}
+	if len(results) > 0 {
+		b.WriteString("\n")
+	}
+
+	summary := validator.Summarize(results)
+	status := "VALID"
+	if summary.Invalid > 0 {
+		status = "INVALID"
+	}
+	b.WriteString(fmt.Sprintf("%d skills (%d invalid), %d errors, %d warnings - %s\n", summary.Skills, summary.Invalid, summary.Errors, summary.Warnings, status))
+	return b.String()
+}
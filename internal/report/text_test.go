@@ -25,7 +25,46 @@ func TestRenderText(t *testing.T) {
 	if !strings.Contains(out, "ERR") || !strings.Contains(out, "WARN") {
 		t.Fatalf("expected findings, got %q", out)
 	}
+	if !strings.Contains(out, "- ERR SKILL.md:3 bad") {
+		t.Fatalf("expected file and line location, got %q", out)
+	}
 	if !strings.Contains(out, "1 errors, 1 warnings - INVALID") {
 		t.Fatalf("unexpected summary: %q", out)
 	}
 }
+
+func TestRenderTextReport(t *testing.T) {
+	results := []validator.Result{
+		{Path: "/tmp/a", Valid: true},
+		{
+			Path:  "/tmp/b",
+			Valid: false,
+			Errors: []validator.Finding{
+				{Level: validator.LevelError, Code: "ERR", Message: "bad", File: "SKILL.md", Line: 3},
+			},
+		},
+	}
+	out := RenderTextReport(results)
+	if !strings.Contains(out, "/tmp/a\n0 errors, 0 warnings - VALID") {
+		t.Fatalf("expected grouped section for /tmp/a, got %q", out)
+	}
+	if !strings.Contains(out, "/tmp/b\nErrors\n- ERR SKILL.md:3 bad") {
+		t.Fatalf("expected grouped section for /tmp/b, got %q", out)
+	}
+	if !strings.HasSuffix(out, "2 skills (1 invalid), 1 errors, 0 warnings - INVALID\n") {
+		t.Fatalf("unexpected summary: %q", out)
+	}
+}
+
+func TestRenderTextColumn(t *testing.T) {
+	result := validator.Result{
+		Path: "/tmp/skill",
+		Errors: []validator.Finding{
+			{Level: validator.LevelError, Code: "ERR", Message: "bad", File: "SKILL.md", Line: 3, Column: 7, EndLine: 3, EndColumn: 12},
+		},
+	}
+	out := RenderText(result)
+	if !strings.Contains(out, "- ERR SKILL.md:3:7 bad") {
+		t.Fatalf("expected column in location, got %q", out)
+	}
+}
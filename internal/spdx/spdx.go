@@ -0,0 +1,201 @@
+// Package spdx parses SPDX license expressions such as "Apache-2.0 OR MIT"
+// against an embedded copy of the SPDX license and exception lists.
+package spdx
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//go:embed licenses.txt
+var licenseList string
+
+//go:embed exceptions.txt
+var exceptionList string
+
+var (
+	licenses   = index(licenseList)
+	exceptions = index(exceptionList)
+
+	refPattern   = regexp.MustCompile(`^(DocumentRef-[A-Za-z0-9.\-]+:)?LicenseRef-[A-Za-z0-9.\-]+$`)
+	tokenPattern = regexp.MustCompile(`\(|\)|[^\s()]+`)
+)
+
+// index maps the lower-cased identifiers of a list to their canonical form;
+// SPDX identifiers are matched case-insensitively.
+func index(list string) map[string]string {
+	ids := make(map[string]string)
+	for _, id := range strings.Fields(list) {
+		ids[strings.ToLower(id)] = id
+	}
+	return ids
+}
+
+// Expression is a parsed license expression. Leaves have a License, with
+// OrLater set for "+" and Exception for "WITH"; other nodes combine Left and
+// Right with Op, "AND" or "OR".
+type Expression struct {
+	License   string
+	OrLater   bool
+	Exception string
+
+	Op          string
+	Left, Right *Expression
+}
+
+// Licenses returns the license identifiers of the expression in order.
+func (e *Expression) Licenses() []string {
+	if e.Op == "" {
+		return []string{e.License}
+	}
+	return append(e.Left.Licenses(), e.Right.Licenses()...)
+}
+
+// Satisfied reports whether the expression can be complied with using only
+// the leaves for which ok returns true: both sides of AND must be, and one
+// side of OR.
+func (e *Expression) Satisfied(ok func(leaf *Expression) bool) bool {
+	switch e.Op {
+	case "AND":
+		return e.Left.Satisfied(ok) && e.Right.Satisfied(ok)
+	case "OR":
+		return e.Left.Satisfied(ok) || e.Right.Satisfied(ok)
+	}
+	return ok(e)
+}
+
+// Canonical returns the canonical spelling of a known license identifier.
+func Canonical(id string) (string, bool) {
+	canonical, ok := licenses[strings.ToLower(id)]
+	return canonical, ok
+}
+
+// Family returns id in lower case without the "+", "-only" and "-or-later"
+// suffixes that select versions of a license, so that GPL-2.0, GPL-2.0+,
+// GPL-2.0-only and GPL-2.0-or-later share a family. LicenseRef- and
+// DocumentRef- identifiers are only lower-cased.
+func Family(id string) string {
+	family := strings.ToLower(id)
+	if refPattern.MatchString(id) {
+		return family
+	}
+	family = strings.TrimSuffix(family, "+")
+	for _, suffix := range []string{"-only", "-or-later"} {
+		if trimmed, ok := strings.CutSuffix(family, suffix); ok {
+			return trimmed
+		}
+	}
+	return family
+}
+
+// Parse parses an SPDX license expression. Identifiers are returned in their
+// canonical spelling; LicenseRef- and DocumentRef- identifiers are kept as
+// written.
+func Parse(expr string) (*Expression, error) {
+	p := &parser{tokens: tokenPattern.FindAllString(expr, -1)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("empty license expression")
+	}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return e, nil
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *parser) or() (*Expression, error) {
+	return p.binary("OR", p.and)
+}
+
+func (p *parser) and() (*Expression, error) {
+	return p.binary("AND", p.with)
+}
+
+func (p *parser) binary(op string, operand func() (*Expression, error)) (*Expression, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == op {
+		p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &Expression{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) with() (*Expression, error) {
+	e, err := p.primary()
+	if err != nil || p.peek() != "WITH" {
+		return e, err
+	}
+	p.next()
+	if e.Op != "" {
+		return nil, errors.New("WITH must follow a single license")
+	}
+	id := p.next()
+	exception, ok := exceptions[strings.ToLower(id)]
+	if !ok {
+		if id == "" {
+			return nil, errors.New("missing exception after WITH")
+		}
+		return nil, fmt.Errorf("unknown license exception %q", id)
+	}
+	e.Exception = exception
+	return e, nil
+}
+
+func (p *parser) primary() (*Expression, error) {
+	token := p.next()
+	switch token {
+	case "":
+		return nil, errors.New("unexpected end of expression")
+	case "(":
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		return e, nil
+	case ")", "AND", "OR", "WITH":
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+	if refPattern.MatchString(token) {
+		return &Expression{License: token}, nil
+	}
+	id, orLater := strings.CutSuffix(token, "+")
+	license, ok := Canonical(id)
+	if !ok {
+		return nil, fmt.Errorf("unknown license identifier %q", token)
+	}
+	return &Expression{License: license, OrLater: orLater}, nil
+}
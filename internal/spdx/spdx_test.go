@@ -0,0 +1,82 @@
+package spdx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		expr     string
+		licenses []string
+	}{
+		{"MIT", []string{"MIT"}},
+		{"mit", []string{"MIT"}},
+		{"Apache-2.0 OR MIT", []string{"Apache-2.0", "MIT"}},
+		{"(MIT OR Apache-2.0) AND BSD-3-Clause", []string{"MIT", "Apache-2.0", "BSD-3-Clause"}},
+		{"GPL-2.0-or-later WITH Classpath-exception-2.0", []string{"GPL-2.0-or-later"}},
+		{"LGPL-2.1+", []string{"LGPL-2.1"}},
+		{"LicenseRef-Proprietary", []string{"LicenseRef-Proprietary"}},
+		{"DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2", []string{"DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2"}},
+	}
+	for _, tc := range cases {
+		e, err := Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.expr, err)
+		}
+		if got := e.Licenses(); !reflect.DeepEqual(got, tc.licenses) {
+			t.Fatalf("%q: expected %v, got %v", tc.expr, tc.licenses, got)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"":                                 "empty",
+		"MITT":                             `unknown license identifier "MITT"`,
+		"MIT OR":                           "unexpected end",
+		"MIT Apache-2.0":                   `unexpected "Apache-2.0"`,
+		"(MIT OR Apache-2.0":               "missing closing parenthesis",
+		"MIT)":                             `unexpected ")"`,
+		"MIT WITH Nope":                    `unknown license exception "Nope"`,
+		"(MIT OR ISC) WITH LLVM-exception": "WITH must follow a single license",
+		"Complete terms in LICENSE.txt":    `unknown license identifier "Complete"`,
+	}
+	for expr, want := range cases {
+		_, err := Parse(expr)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: expected error containing %q, got %v", expr, want, err)
+		}
+	}
+}
+
+func TestSatisfied(t *testing.T) {
+	e, err := Parse("(MIT OR GPL-3.0-only) AND Apache-2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	permissive := func(leaf *Expression) bool { return leaf.License != "GPL-3.0-only" }
+	if !e.Satisfied(permissive) {
+		t.Fatal("expected MIT AND Apache-2.0 to satisfy the policy")
+	}
+	if e.Satisfied(func(leaf *Expression) bool { return leaf.License != "Apache-2.0" }) {
+		t.Fatal("expected the AND to require Apache-2.0")
+	}
+}
+
+func TestFamily(t *testing.T) {
+	cases := map[string]string{
+		"GPL-2.0":                "gpl-2.0",
+		"GPL-2.0+":               "gpl-2.0",
+		"GPL-2.0-only":           "gpl-2.0",
+		"gpl-2.0-or-later":       "gpl-2.0",
+		"MIT":                    "mit",
+		"LicenseRef-Custom-only": "licenseref-custom-only",
+	}
+	for id, want := range cases {
+		if got := Family(id); got != want {
+			t.Errorf("%q: expected %q, got %q", id, want, got)
+		}
+	}
+}
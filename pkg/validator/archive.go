@@ -0,0 +1,336 @@
+package validator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMaxArchiveSize is the uncompressed size limit for archives when
+// Options.MaxArchiveSize is zero.
+const DefaultMaxArchiveSize = 100 << 20
+
+var (
+	archiveExtensions = []string{".zip", ".skill", ".tar.gz", ".tgz"}
+	drivePattern      = regexp.MustCompile(`^[A-Za-z]:`)
+
+	errArchiveTooLarge = errors.New("archive too large")
+)
+
+// IsArchive reports whether path names a skill archive by its extension:
+// .zip, .skill (a zip file), .tar.gz or .tgz.
+func IsArchive(path string) bool {
+	lower := strings.ToLower(path)
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateArchive validates the packaged skill in file without extracting
+// it. The archive must hold a single top-level skill directory; entries with
+// unsafe paths and symlinks are reported and left out, and reading stops at
+// Options.MaxArchiveSize uncompressed bytes. The result has Archive set and
+// its findings name archive entry paths, such as "my-skill/SKILL.md".
+func ValidateArchive(file string, opts Options) (Result, error) {
+	absPath, err := filepath.Abs(file)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{Path: absPath, Archive: true}
+	if _, err := os.Stat(absPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			addError(&result, codePathNotFound, fmt.Sprintf("Path '%s' does not exist.", file), "", span{})
+			finalizeResult(&result, opts, nil)
+			return result, nil
+		}
+		return result, err
+	}
+
+	limit := opts.MaxArchiveSize
+	if limit <= 0 {
+		limit = DefaultMaxArchiveSize
+	}
+	archive := newArchiveFS()
+	if strings.HasSuffix(strings.ToLower(absPath), ".zip") || strings.HasSuffix(strings.ToLower(absPath), ".skill") {
+		err = readZip(absPath, archive, limit)
+	} else {
+		err = readTarGz(absPath, archive, limit)
+	}
+	if errors.Is(err, errArchiveTooLarge) {
+		addError(&result, codeArchiveTooLarge, fmt.Sprintf("Archive expands to more than %d bytes.", limit), "", span{})
+		finalizeResult(&result, opts, nil)
+		return result, nil
+	}
+	if err != nil {
+		addError(&result, codeArchiveInvalid, fmt.Sprintf("Archive cannot be read: %s", err.Error()), "", span{})
+		finalizeResult(&result, opts, nil)
+		return result, nil
+	}
+
+	for _, name := range archive.unsafe {
+		addError(&result, codeArchiveUnsafePath, fmt.Sprintf("Archive entry '%s' has an absolute path or '..' segments.", name), name, span{})
+	}
+	for _, name := range archive.links {
+		addError(&result, codeArchiveSymlink, fmt.Sprintf("Archive entry '%s' is a link.", name), name, span{})
+	}
+
+	root, dirName := ".", ""
+	if tops := archive.children("."); len(tops) == 1 && archive.entries[tops[0]].mode.IsDir() {
+		root, dirName = tops[0], tops[0]
+	} else {
+		addError(&result, codeArchiveRootNotSingleDir, fmt.Sprintf("Archive must contain a single top-level directory; found %d entries.", len(tops)), "", span{})
+	}
+	for _, name := range archive.names() {
+		if path.Base(name) == "SKILL.md" && name != path.Join(root, "SKILL.md") {
+			addError(&result, codeArchiveSkillMDMisplaced, fmt.Sprintf("Archive entry '%s' is not at the skill root '%s'.", name, path.Join(root, "SKILL.md")), name, span{})
+		}
+	}
+
+	skill, _, err := validateFS(archive, root, filepath.Base(absPath), dirName, nil, opts)
+	if err != nil {
+		return result, err
+	}
+	// FixSkill cannot rewrite files inside an archive, so its findings are
+	// neither fixable nor carry edits.
+	for _, list := range [][]Finding{skill.Errors, skill.Warnings, skill.Suppressed} {
+		for i := range list {
+			if list[i].File != "" {
+				list[i].File = path.Join(root, list[i].File)
+			}
+			list[i].Fixable = false
+			list[i].Suggestions = nil
+		}
+	}
+	result.Errors = append(result.Errors, skill.Errors...)
+	result.Warnings = append(result.Warnings, skill.Warnings...)
+	result.Suppressed = skill.Suppressed
+	result.Name = skill.Name
+	result.Description = skill.Description
+	result.AllowedTools = skill.AllowedTools
+	finalizeResult(&result, opts, nil)
+	return result, nil
+}
+
+func readZip(file string, archive *archiveFS, limit int64) error {
+	r, err := zip.OpenReader(file)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.UncompressedSize64 > uint64(limit) {
+			return errArchiveTooLarge
+		}
+		var data []byte
+		if f.Mode().IsRegular() {
+			rc, err := f.Open()
+			if err != nil {
+				return err
+			}
+			data, err = readLimited(rc, archive, limit)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+		archive.add(f.Name, f.Mode(), f.Modified, data)
+	}
+	return nil
+}
+
+func readTarGz(file string, archive *archiveFS, limit int64) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		mode := header.FileInfo().Mode()
+		if header.Typeflag == tar.TypeLink {
+			mode |= fs.ModeSymlink
+		}
+		var data []byte
+		if mode.IsRegular() {
+			if header.Size > limit {
+				return errArchiveTooLarge
+			}
+			if data, err = readLimited(tr, archive, limit); err != nil {
+				return err
+			}
+		}
+		archive.add(header.Name, mode, header.ModTime, data)
+	}
+}
+
+// readLimited reads an entry while keeping the archive's running total
+// within limit, whatever sizes the headers claim.
+func readLimited(r io.Reader, archive *archiveFS, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit-archive.size+1))
+	if err != nil {
+		return nil, err
+	}
+	archive.size += int64(len(data))
+	if archive.size > limit {
+		return nil, errArchiveTooLarge
+	}
+	return data, nil
+}
+
+// archiveFS is an in-memory fs.FS of the safe regular files and directories
+// of an archive. Directories implied by file paths are added as well.
+type archiveFS struct {
+	entries map[string]*archiveEntry
+	unsafe  []string
+	links   []string
+	size    int64
+}
+
+type archiveEntry struct {
+	name    string
+	mode    fs.FileMode
+	modTime time.Time
+	data    []byte
+}
+
+func newArchiveFS() *archiveFS {
+	return &archiveFS{entries: map[string]*archiveEntry{
+		".": {name: ".", mode: fs.ModeDir | 0o755},
+	}}
+}
+
+// add records an entry. Entries with unsafe names and links are set aside
+// for reporting; macOS resource fork folders are ignored altogether.
+func (a *archiveFS) add(raw string, mode fs.FileMode, modTime time.Time, data []byte) {
+	name := strings.ReplaceAll(raw, "\\", "/")
+	if strings.HasPrefix(name, "/") || drivePattern.MatchString(name) || hasDotDot(name) {
+		a.unsafe = append(a.unsafe, raw)
+		return
+	}
+	name = path.Clean(strings.TrimSuffix(name, "/"))
+	if name == "." || name == "__MACOSX" || strings.HasPrefix(name, "__MACOSX/") {
+		return
+	}
+	if mode&fs.ModeSymlink != 0 {
+		a.links = append(a.links, name)
+		return
+	}
+	if !mode.IsDir() && !mode.IsRegular() {
+		return
+	}
+	a.entries[name] = &archiveEntry{name: name, mode: mode, modTime: modTime, data: data}
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if _, ok := a.entries[dir]; !ok {
+			a.entries[dir] = &archiveEntry{name: dir, mode: fs.ModeDir | 0o755, modTime: modTime}
+		}
+	}
+}
+
+// names returns the paths of all entries in sorted order.
+func (a *archiveFS) names() []string {
+	names := make([]string, 0, len(a.entries))
+	for name := range a.entries {
+		if name != "." {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (a *archiveFS) children(dir string) []string {
+	children := make([]string, 0)
+	for _, name := range a.names() {
+		if path.Dir(name) == dir {
+			children = append(children, name)
+		}
+	}
+	return children
+}
+
+func (a *archiveFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	entry, ok := a.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if entry.mode.IsDir() {
+		return &archiveDir{archive: a, entry: entry}, nil
+	}
+	return &archiveFile{entry: entry, Reader: bytes.NewReader(entry.data)}, nil
+}
+
+func (e *archiveEntry) Name() string       { return path.Base(e.name) }
+func (e *archiveEntry) Size() int64        { return int64(len(e.data)) }
+func (e *archiveEntry) Mode() fs.FileMode  { return e.mode }
+func (e *archiveEntry) ModTime() time.Time { return e.modTime }
+func (e *archiveEntry) IsDir() bool        { return e.mode.IsDir() }
+func (e *archiveEntry) Sys() any           { return nil }
+
+type archiveFile struct {
+	entry *archiveEntry
+	*bytes.Reader
+}
+
+func (f *archiveFile) Stat() (fs.FileInfo, error) { return f.entry, nil }
+func (f *archiveFile) Close() error               { return nil }
+
+type archiveDir struct {
+	archive *archiveFS
+	entry   *archiveEntry
+	read    int
+}
+
+func (d *archiveDir) Stat() (fs.FileInfo, error) { return d.entry, nil }
+func (d *archiveDir) Close() error               { return nil }
+
+func (d *archiveDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.entry.name, Err: errors.New("is a directory")}
+}
+
+func (d *archiveDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	children := d.archive.children(d.entry.name)[d.read:]
+	if n > 0 && len(children) > n {
+		children = children[:n]
+	}
+	if n > 0 && len(children) == 0 {
+		return nil, io.EOF
+	}
+	entries := make([]fs.DirEntry, 0, len(children))
+	for _, name := range children {
+		entries = append(entries, fs.FileInfoToDirEntry(d.archive.entries[name]))
+	}
+	d.read += len(entries)
+	return entries, nil
+}
@@ -0,0 +1,215 @@
+package validator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type archiveEntryFixture struct {
+	name string
+	body string
+	link string
+}
+
+const archiveSkillMD = "---\nname: my-skill\ndescription: Packaged.\n---\nBody\n"
+
+func writeZip(t *testing.T, name string, entries []archiveEntryFixture) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+		body := entry.body
+		if entry.link != "" {
+			header.SetMode(os.ModeSymlink | 0o777)
+			body = entry.link
+		}
+		fw, err := w.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func writeTarGz(t *testing.T, name string, entries []archiveEntryFixture) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0o644, Size: int64(len(entry.body)), Typeflag: tar.TypeReg}
+		if entry.link != "" {
+			header.Typeflag, header.Linkname, header.Size = tar.TypeLink, entry.link, 0
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(entry.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func findingFiles(findings []Finding) map[string]string {
+	files := make(map[string]string)
+	for _, finding := range findings {
+		files[finding.Code+" "+finding.File] = finding.Message
+	}
+	return files
+}
+
+func TestValidateArchiveZip(t *testing.T) {
+	file := writeZip(t, "bundle.skill", []archiveEntryFixture{
+		{name: "my-skill/"},
+		{name: "my-skill/SKILL.md", body: archiveSkillMD},
+		{name: "my-skill/scripts/run.sh", body: "echo hi\n"},
+		{name: "__MACOSX/my-skill/._SKILL.md", body: "junk"},
+	})
+
+	result, err := ValidateSkill(file, Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Valid || len(result.Errors) != 0 || len(result.Warnings) != 0 {
+		t.Fatalf("expected clean result, got %#v", result)
+	}
+	if abs, _ := filepath.Abs(file); result.Path != abs {
+		t.Fatalf("expected path %s, got %s", abs, result.Path)
+	}
+}
+
+func TestValidateArchiveFindingsNameEntries(t *testing.T) {
+	file := writeZip(t, "bundle.zip", []archiveEntryFixture{
+		{name: "my-skill/SKILL.md", body: "---\nname: other\ndescription: Packaged.\n---\nSee [the guide](references/guide.md).\n"},
+		{name: "my-skill/references/guide.md", link: "../../../etc/passwd"},
+		{name: "../evil.sh", body: "rm -rf /\n"},
+		{name: "/etc/cron.d/job", body: "* * * * * root true\n"},
+		{name: "my-skill/docs/SKILL.md", body: archiveSkillMD},
+	})
+
+	result, err := ValidateArchive(file, Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Archive {
+		t.Fatal("expected the result to be marked as an archive")
+	}
+	errors := findingFiles(result.Errors)
+	for _, key := range []string{
+		"ARCHIVE_UNSAFE_PATH ../evil.sh",
+		"ARCHIVE_UNSAFE_PATH /etc/cron.d/job",
+		"ARCHIVE_SYMLINK my-skill/references/guide.md",
+		"ARCHIVE_SKILL_MD_MISPLACED my-skill/docs/SKILL.md",
+		"NAME_MISMATCH_DIRECTORY my-skill/SKILL.md",
+	} {
+		if _, ok := errors[key]; !ok {
+			t.Fatalf("expected %s, got %#v", key, result.Errors)
+		}
+	}
+	if _, ok := findingFiles(result.Warnings)["REF_MISSING_FILE my-skill/SKILL.md"]; !ok {
+		t.Fatalf("expected REF_MISSING_FILE on the entry, got %#v", result.Warnings)
+	}
+	for _, finding := range result.Errors {
+		if finding.Code == codeNameMismatchDirectory && finding.Line != 2 {
+			t.Fatalf("expected line 2, got %#v", finding)
+		}
+		if finding.Fixable || len(finding.Suggestions) != 0 {
+			t.Fatalf("expected findings inside an archive to be unfixable, got %#v", finding)
+		}
+	}
+}
+
+func TestValidateArchiveRoot(t *testing.T) {
+	file := writeTarGz(t, "bundle.tar.gz", []archiveEntryFixture{
+		{name: "SKILL.md", body: archiveSkillMD},
+		{name: "README.md", body: "# Readme\n"},
+	})
+	result, err := ValidateSkill(file, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codeArchiveRootNotSingleDir)
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected only the root error, got %#v", result.Errors)
+	}
+}
+
+func TestValidateArchiveTarLinks(t *testing.T) {
+	file := writeTarGz(t, "bundle.tgz", []archiveEntryFixture{
+		{name: "my-skill/SKILL.md", body: archiveSkillMD},
+		{name: "my-skill/assets/copy.md", link: "my-skill/SKILL.md"},
+	})
+	result, err := ValidateArchive(file, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := findingFiles(result.Errors)["ARCHIVE_SYMLINK my-skill/assets/copy.md"]; !ok {
+		t.Fatalf("expected ARCHIVE_SYMLINK, got %#v", result.Errors)
+	}
+}
+
+func TestValidateArchiveSizeLimit(t *testing.T) {
+	file := writeZip(t, "bundle.zip", []archiveEntryFixture{
+		{name: "my-skill/SKILL.md", body: archiveSkillMD},
+		{name: "my-skill/assets/big.txt", body: string(make([]byte, 4096))},
+	})
+	result, err := ValidateArchive(file, Options{MaxArchiveSize: 1024})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codeArchiveTooLarge)
+
+	result, err = ValidateArchive(file, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Valid {
+		t.Fatalf("expected valid result under the default limit, got %#v", result)
+	}
+}
+
+func TestValidateArchiveInvalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "broken.zip")
+	if err := os.WriteFile(file, []byte("not a zip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := ValidateSkill(file, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codeArchiveInvalid)
+
+	result, err = ValidateArchive(filepath.Join(t.TempDir(), "missing.zip"), Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codePathNotFound)
+}
@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DiscoverSkills expands each path into the skill directories found beneath it.
+// A directory containing SKILL.md is a skill and is not descended into further.
+// Paths that do not exist, are not directories, or contain no skills at all are
+// returned unchanged so that ValidateSkill can report on them.
+func DiscoverSkills(paths []string) ([]string, error) {
+	skills := make([]string, 0, len(paths))
+	seen := make(map[string]struct{})
+	add := func(path string) {
+		key := path
+		if abs, err := filepath.Abs(path); err == nil {
+			key = abs
+		}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		skills = append(skills, path)
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			add(path)
+			continue
+		}
+		found, err := findSkillDirs(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			add(path)
+			continue
+		}
+		for _, dir := range found {
+			add(dir)
+		}
+	}
+	return skills, nil
+}
+
+// ValidateSkills discovers every skill below paths and validates each of them.
+func ValidateSkills(paths []string, opts Options) ([]Result, error) {
+	skills, err := DiscoverSkills(paths)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, 0, len(skills))
+	for _, skill := range skills {
+		result, err := ValidateSkill(skill, opts)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Summarize aggregates the finding counts of several results.
+func Summarize(results []Result) Summary {
+	summary := Summary{Skills: len(results)}
+	for _, result := range results {
+		if result.Valid {
+			summary.Valid++
+		} else {
+			summary.Invalid++
+		}
+		summary.Errors += len(result.Errors)
+		summary.Warnings += len(result.Warnings)
+	}
+	return summary
+}
+
+func findSkillDirs(root string) ([]string, error) {
+	dirs := make([]string, 0)
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path != root && errors.Is(err, fs.ErrPermission) {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(entry.Name(), ".") {
+			return fs.SkipDir
+		}
+		if _, err := os.Lstat(filepath.Join(path, "SKILL.md")); err == nil {
+			dirs = append(dirs, path)
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dirs, nil
+}
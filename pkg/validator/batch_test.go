@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverSkills(t *testing.T) {
+	root := filepath.Dir(fixturePath(t, "valid-minimal"))
+	skills, err := DiscoverSkills([]string{root})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, skill := range skills {
+		found[filepath.Base(skill)] = true
+	}
+	if !found["valid-minimal"] || !found["reference-warnings"] {
+		t.Fatalf("expected fixtures to be discovered, got %v", skills)
+	}
+	if found["invalid-missing-skillmd"] {
+		t.Fatalf("directory without SKILL.md should not be discovered: %v", skills)
+	}
+	if found["references"] {
+		t.Fatalf("skill subdirectories should not be descended into: %v", skills)
+	}
+}
+
+func TestDiscoverSkillsKeepsNonSkillPaths(t *testing.T) {
+	missing := fixturePath(t, "does-not-exist")
+	empty := fixturePath(t, "invalid-missing-skillmd")
+	skill := fixturePath(t, "valid-minimal")
+	skills, err := DiscoverSkills([]string{missing, empty, skill, skill})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(skills) != 3 || skills[0] != missing || skills[1] != empty || skills[2] != skill {
+		t.Fatalf("unexpected skills: %v", skills)
+	}
+}
+
+func TestValidateSkillsSummary(t *testing.T) {
+	results, err := ValidateSkills([]string{
+		fixturePath(t, "valid-minimal"),
+		fixturePath(t, "invalid-name-mismatch"),
+	}, Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary := Summarize(results)
+	if summary.Skills != 2 || summary.Valid != 1 || summary.Invalid != 1 {
+		t.Fatalf("unexpected summary: %#v", summary)
+	}
+	if summary.Errors == 0 {
+		t.Fatalf("expected errors to be counted: %#v", summary)
+	}
+}
@@ -0,0 +1,120 @@
+package validator
+
+// CodeInfo describes a finding code the validator can emit.
+type CodeInfo struct {
+	Code  string       `json:"code"`
+	Level FindingLevel `json:"level"`
+	// Category groups related codes, such as "name" or "references".
+	// Codes of registered rules are in the "custom" category.
+	Category string `json:"category"`
+	// Fixable reports whether FixSkill can repair findings with the code.
+	Fixable     bool   `json:"fixable"`
+	Description string `json:"description"`
+}
+
+// CategoryCustom is the category of codes reported by registered rules.
+const CategoryCustom = "custom"
+
+var codeInfos = []CodeInfo{
+	{codePathNotFound, LevelError, "structure", false, "The specified path does not exist"},
+	{codePathNotDirectory, LevelError, "structure", false, "The specified path is not a directory"},
+	{codeSkillMDMissing, LevelError, "structure", false, "No SKILL.md file found in the directory"},
+	{codeSkillMDNotFile, LevelError, "structure", false, "SKILL.md exists but is a directory"},
+	{codeSkillMDSymlinkInvalid, LevelError, "structure", false, "SKILL.md symlink cannot be resolved"},
+	{codeSkillMDSymlinkEscapes, LevelError, "structure", false, "SKILL.md symlink points outside the skill directory"},
+	{codeArchiveInvalid, LevelError, "archive", false, "Archive cannot be read as zip or tar.gz"},
+	{codeArchiveTooLarge, LevelError, "archive", false, "Archive expands beyond the uncompressed size limit"},
+	{codeArchiveUnsafePath, LevelError, "archive", false, "Archive entry has an absolute path or .. segments"},
+	{codeArchiveSymlink, LevelError, "archive", false, "Archive entry is a symlink or hard link"},
+	{codeArchiveRootNotSingleDir, LevelError, "archive", false, "Archive does not contain a single top-level directory"},
+	{codeArchiveSkillMDMisplaced, LevelError, "archive", false, "Archive has a SKILL.md outside the skill root"},
+	{codeFrontmatterStart, LevelError, "frontmatter", false, "File does not begin with ---"},
+	{codeFrontmatterEnd, LevelError, "frontmatter", false, "No closing --- delimiter found"},
+	{codeFrontmatterEmpty, LevelError, "frontmatter", false, "No content between --- delimiters"},
+	{codeFrontmatterInvalidYAML, LevelError, "frontmatter", false, "YAML syntax error"},
+	{codeFrontmatterNotMapping, LevelError, "frontmatter", false, "YAML is not a key-value mapping"},
+	{codeFrontmatterDuplicateKey, LevelError, "frontmatter", false, "A key appears more than once in the same mapping"},
+	{codeScriptsNotDir, LevelError, "structure", false, "scripts exists but is not a directory"},
+	{codeReferencesNotDir, LevelError, "structure", false, "references exists but is not a directory"},
+	{codeAssetsNotDir, LevelError, "structure", false, "assets exists but is not a directory"},
+	{codeNameMissing, LevelError, "name", false, "Required name field not present"},
+	{codeNameNotString, LevelError, "name", false, "name value is not a string"},
+	{codeNameTooShort, LevelError, "name", false, "name is empty (0 characters)"},
+	{codeNameTooLong, LevelError, "name", false, "name exceeds 64 characters"},
+	{codeNameInvalidChars, LevelError, "name", true, "name contains invalid characters"},
+	{codeNameStartsWithHyphen, LevelError, "name", true, "name begins with -"},
+	{codeNameEndsWithHyphen, LevelError, "name", true, "name ends with -"},
+	{codeNameConsecutiveHyphens, LevelError, "name", true, "name contains --"},
+	{codeNameMismatchDirectory, LevelError, "name", true, "name does not match the directory name"},
+	{codeDescriptionMissing, LevelError, "description", false, "Required description field not present"},
+	{codeDescriptionNotString, LevelError, "description", false, "description value is not a string"},
+	{codeDescriptionTooShort, LevelError, "description", false, "description is empty"},
+	{codeDescriptionTooLong, LevelError, "description", false, "description exceeds 1024 characters"},
+	{codeCompatibilityNotString, LevelError, "fields", false, "compatibility is not a string"},
+	{codeCompatibilityTooShort, LevelError, "fields", false, "compatibility is empty"},
+	{codeCompatibilityTooLong, LevelError, "fields", false, "compatibility exceeds 500 characters"},
+	{codeLicenseNotString, LevelError, "fields", false, "license is not a string"},
+	{codeLicenseNotAllowed, LevelError, "fields", false, "license is not allowed by the license policy"},
+	{codeLicenseInvalidSPDX, LevelError, "fields", false, "license is neither an SPDX expression nor a bundled license file"},
+	{codeMetadataNotObject, LevelError, "fields", false, "metadata is not a key-value object"},
+	{codeMetadataValueNotString, LevelError, "fields", true, "metadata contains non-string values"},
+	{codeMetadataKeyMissing, LevelError, "fields", false, "metadata lacks a key the metadata schema requires"},
+	{codeMetadataValueInvalid, LevelError, "fields", false, "metadata value does not match the metadata schema"},
+	{codeMetadataKeyUnknown, LevelError, "fields", false, "metadata key is not declared in the metadata schema"},
+	{codeAllowedToolsNotString, LevelError, "fields", false, "allowed-tools is not a string"},
+	{codeAllowedToolsEmpty, LevelError, "fields", false, "allowed-tools is empty or whitespace-only"},
+	{codeSkillMDSymlink, LevelWarning, "structure", false, "SKILL.md is a symlink (informational)"},
+	{codeSkillMDTooLongLines, LevelWarning, "content", false, "SKILL.md exceeds 500 lines"},
+	{codeSkillMDMissingBody, LevelWarning, "structure", false, "No content after frontmatter"},
+	{codeUnknownTopLevelKey, LevelWarning, "frontmatter", false, "Unrecognized keys in frontmatter"},
+	{codeAllowedToolsInvalid, LevelWarning, "fields", false, "allowed-tools entry is not a tool name with an optional (pattern)"},
+	{codeAllowedToolsDuplicate, LevelWarning, "fields", false, "allowed-tools lists the same tool more than once"},
+	{codeAllowedToolsUnknown, LevelWarning, "fields", false, "allowed-tools names a tool that is not in known-tools"},
+	{codeAllowedToolsWhitespace, LevelWarning, "fields", true, "allowed-tools entries are not separated by single spaces"},
+	{codeScriptsDirEmpty, LevelWarning, "structure", false, "scripts/ directory exists but is empty"},
+	{codeReferencesDirEmpty, LevelWarning, "structure", false, "references/ directory exists but is empty"},
+	{codeAssetsDirEmpty, LevelWarning, "structure", false, "assets/ directory exists but is empty"},
+	{codeRefContainsDotDot, LevelWarning, "references", false, "Reference path contains .."},
+	{codeRefTooDeep, LevelWarning, "references", false, "Reference path is more than one level deep"},
+	{codeRefMissingFile, LevelWarning, "references", false, "Referenced file does not exist"},
+	{codeRefEscapesRoot, LevelWarning, "references", false, "Reference resolves outside skill directory"},
+	{codeYAMLAnchor, LevelWarning, "portability", false, "Frontmatter defines a YAML anchor"},
+	{codeYAMLAlias, LevelWarning, "portability", false, "Frontmatter uses a YAML alias"},
+	{codeYAMLExplicitTag, LevelWarning, "portability", false, "Frontmatter uses an explicit YAML tag"},
+	{codeYAMLMergeKey, LevelWarning, "portability", false, "Frontmatter uses the YAML merge key <<"},
+	{codeYAML11Boolean, LevelWarning, "portability", false, "String field is a YAML 1.1 boolean such as yes, no or on"},
+	{codeYAMLMultipleDocuments, LevelWarning, "portability", false, "Frontmatter contains a YAML document marker"},
+	{codeSuppressionUnused, LevelWarning, "suppression", false, "Inline suppression comment matches no finding"},
+}
+
+// Codes returns every finding code the validator can emit: the built-in
+// codes, errors first, followed by those of registered rules.
+func Codes() []CodeInfo {
+	codes := append([]CodeInfo(nil), codeInfos...)
+	for _, rule := range Rules() {
+		if _, ok := rule.(builtinRule); !ok {
+			codes = append(codes, CodeInfo{Code: rule.ID(), Level: rule.DefaultSeverity(), Category: CategoryCustom, Description: rule.Doc()})
+		}
+	}
+	return codes
+}
+
+// DisabledCodes returns the codes whose findings opts discards: codes turned
+// off in Severity, the portability codes unless Portability is set, and codes
+// reported as warnings when NoWarn is set.
+func DisabledCodes(opts Options) []string {
+	disabled := make([]string, 0)
+	for _, info := range Codes() {
+		level := info.Level
+		if override, ok := opts.Severity[info.Code]; ok {
+			level = override
+		}
+		switch {
+		case level == LevelOff,
+			info.Category == "portability" && !opts.Portability,
+			level == LevelWarning && opts.NoWarn:
+			disabled = append(disabled, info.Code)
+		}
+	}
+	return disabled
+}
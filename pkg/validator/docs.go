@@ -0,0 +1,429 @@
+package validator
+
+import "strings"
+
+// Explanation documents a finding code in depth, for `sklint explain`.
+type Explanation struct {
+	// Details explains what the code checks and why it matters.
+	Details string `json:"details"`
+	// Bad and Good are examples, usually of SKILL.md, that do and do not
+	// trigger the code. Either may be empty.
+	Bad  string `json:"bad,omitempty"`
+	Good string `json:"good,omitempty"`
+	// Fix describes how to resolve the finding.
+	Fix string `json:"fix"`
+}
+
+// Explainer is implemented by rules that document themselves in depth.
+// Registered rules without it are explained by their Doc alone.
+type Explainer interface {
+	Explain() Explanation
+}
+
+// Explain returns the metadata and long documentation of a code, matched
+// case-insensitively.
+func Explain(code string) (CodeInfo, Explanation, bool) {
+	for _, info := range Codes() {
+		if !strings.EqualFold(info.Code, code) {
+			continue
+		}
+		if doc, ok := codeDocs[info.Code]; ok {
+			return info, doc, true
+		}
+		for _, rule := range Rules() {
+			if explainer, ok := rule.(Explainer); ok && rule.ID() == info.Code {
+				return info, explainer.Explain(), true
+			}
+		}
+		return info, Explanation{Details: info.Description + "."}, true
+	}
+	return CodeInfo{}, Explanation{}, false
+}
+
+var codeDocs = map[string]Explanation{
+	codePathNotFound: {
+		Details: "A path given to sklint does not exist, so there is nothing to validate.",
+		Fix:     "Check the path for typos, or run sklint from the directory the path is relative to.",
+	},
+	codePathNotDirectory: {
+		Details: "A skill is a directory containing SKILL.md. The path given to sklint is a file that is not a supported archive.",
+		Bad:     "sklint my-skill/SKILL.md",
+		Good:    "sklint my-skill",
+		Fix:     "Pass the skill directory, or a .zip, .skill, .tar.gz or .tgz archive of it.",
+	},
+	codeSkillMDMissing: {
+		Details: "Every skill needs a SKILL.md at the root of its directory; agents discover skills by it. The file name is case-sensitive.",
+		Bad:     "my-skill/\n  skill.md",
+		Good:    "my-skill/\n  SKILL.md",
+		Fix:     "Create SKILL.md in the skill directory, or rename the existing file to exactly SKILL.md.",
+	},
+	codeSkillMDNotFile: {
+		Details: "SKILL.md exists but is a directory, so it cannot hold the skill's instructions.",
+		Bad:     "my-skill/\n  SKILL.md/\n    content.md",
+		Good:    "my-skill/\n  SKILL.md",
+		Fix:     "Replace the SKILL.md directory with a Markdown file.",
+	},
+	codeSkillMDSymlinkInvalid: {
+		Details: "SKILL.md is a symlink whose target does not exist, so the skill cannot be loaded.",
+		Fix:     "Point the symlink at an existing file, or replace it with a regular file.",
+	},
+	codeSkillMDSymlinkEscapes: {
+		Details: "SKILL.md is a symlink to a file outside the skill directory. Copying or packaging the skill would lose it.",
+		Bad:     "my-skill/SKILL.md -> ../shared/SKILL.md",
+		Good:    "my-skill/SKILL.md (regular file)",
+		Fix:     "Copy the file into the skill directory, or pass --follow-symlinks if the link is intended.",
+	},
+	codeArchiveInvalid: {
+		Details: "The archive could not be opened. It may be truncated, or its extension does not match its format.",
+		Fix:     "Recreate the archive, for example with `sklint pack`, and make sure .zip and .skill files are zip files and .tar.gz and .tgz files are gzipped tarballs.",
+	},
+	codeArchiveTooLarge: {
+		Details: "The archive expands to more than the uncompressed size limit, 100 MiB by default. Reading stops there to guard against zip bombs.",
+		Fix:     "Remove large assets from the skill, or raise max-archive-size in .sklint.yaml if the size is expected.",
+	},
+	codeArchiveUnsafePath: {
+		Details: "An archive entry has an absolute path or '..' segments. Extracting it would write outside the target directory (\"zip slip\"). Such entries are not read.",
+		Bad:     "my-skill/SKILL.md\n../evil.sh",
+		Good:    "my-skill/SKILL.md\nmy-skill/scripts/setup.sh",
+		Fix:     "Rebuild the archive from the skill directory so that every entry path is relative to it.",
+	},
+	codeArchiveSymlink: {
+		Details: "An archive entry is a symlink or hard link. Links can point anywhere once extracted, so they are not read.",
+		Fix:     "Replace links with the files they point to before packing.",
+	},
+	codeArchiveRootNotSingleDir: {
+		Details: "A skill archive must contain exactly one top-level directory, the skill, so that extracting it creates a single skill directory.",
+		Bad:     "SKILL.md\nscripts/setup.sh",
+		Good:    "my-skill/SKILL.md\nmy-skill/scripts/setup.sh",
+		Fix:     "Archive the skill directory itself rather than its contents, for example with `sklint pack ./my-skill`.",
+	},
+	codeArchiveSkillMDMisplaced: {
+		Details: "The archive contains a SKILL.md that is not directly inside the skill directory. Only my-skill/SKILL.md is loaded; others are ignored or mistaken for nested skills.",
+		Bad:     "my-skill/SKILL.md\nmy-skill/docs/SKILL.md",
+		Good:    "my-skill/SKILL.md\nmy-skill/docs/usage.md",
+		Fix:     "Rename or remove the extra SKILL.md files.",
+	},
+	codeFrontmatterStart: {
+		Details: "SKILL.md must start with YAML frontmatter: a line containing only ---, the YAML, and another ---.",
+		Bad:     "# My Skill\n\nname: my-skill",
+		Good:    "---\nname: my-skill\ndescription: Does one thing well.\n---\n# My Skill",
+		Fix:     "Add the frontmatter block at the very top of the file, with nothing before the first ---.",
+	},
+	codeFrontmatterEnd: {
+		Details: "The frontmatter is opened with --- but never closed, so the whole file would be read as YAML.",
+		Bad:     "---\nname: my-skill\ndescription: Does one thing well.\n# My Skill",
+		Good:    "---\nname: my-skill\ndescription: Does one thing well.\n---\n# My Skill",
+		Fix:     "Add a line containing only --- after the last frontmatter key.",
+	},
+	codeFrontmatterEmpty: {
+		Details: "The frontmatter block contains no keys, but name and description are required.",
+		Bad:     "---\n---\n# My Skill",
+		Good:    "---\nname: my-skill\ndescription: Does one thing well.\n---\n# My Skill",
+		Fix:     "Add the name and description keys.",
+	},
+	codeFrontmatterInvalidYAML: {
+		Details: "The frontmatter is not valid YAML. A common cause is an unquoted value containing ': '.",
+		Bad:     "---\nname: my-skill\ndescription: Usage: run the script\n---",
+		Good:    "---\nname: my-skill\ndescription: \"Usage: run the script\"\n---",
+		Fix:     "Fix the syntax error on the reported line; quote values that contain colons or start with special characters.",
+	},
+	codeFrontmatterNotMapping: {
+		Details: "The frontmatter must be a mapping of keys to values, not a list or a plain string.",
+		Bad:     "---\n- name: my-skill\n- description: Does one thing well.\n---",
+		Good:    "---\nname: my-skill\ndescription: Does one thing well.\n---",
+		Fix:     "Write the frontmatter as key: value lines.",
+	},
+	codeFrontmatterDuplicateKey: {
+		Details: "A key appears twice in the frontmatter or in a nested mapping such as metadata. Which value wins depends on the parser, so the skill may behave differently across agents. This often happens when two edits of SKILL.md are merged. The other rules still run, using the first value of each key.",
+		Bad:     "---\nname: pdf-tools\ndescription: Extracts text.\ndescription: Extracts text and tables.\n---",
+		Good:    "---\nname: pdf-tools\ndescription: Extracts text and tables.\n---",
+		Fix:     "Keep one of the values and delete the other key.",
+	},
+	codeScriptsNotDir: {
+		Details: "scripts is reserved for executable helpers of the skill and must be a directory.",
+		Bad:     "my-skill/\n  SKILL.md\n  scripts",
+		Good:    "my-skill/\n  SKILL.md\n  scripts/\n    setup.sh",
+		Fix:     "Turn scripts into a directory, or rename the file.",
+	},
+	codeReferencesNotDir: {
+		Details: "references is reserved for documentation the agent loads on demand and must be a directory.",
+		Bad:     "my-skill/\n  SKILL.md\n  references",
+		Good:    "my-skill/\n  SKILL.md\n  references/\n    guide.md",
+		Fix:     "Turn references into a directory, or rename the file.",
+	},
+	codeAssetsNotDir: {
+		Details: "assets is reserved for templates and other files used in output and must be a directory.",
+		Bad:     "my-skill/\n  SKILL.md\n  assets",
+		Good:    "my-skill/\n  SKILL.md\n  assets/\n    template.docx",
+		Fix:     "Turn assets into a directory, or rename the file.",
+	},
+	codeNameMissing: {
+		Details: "name identifies the skill and is required.",
+		Bad:     "---\ndescription: Does one thing well.\n---",
+		Good:    "---\nname: my-skill\ndescription: Does one thing well.\n---",
+		Fix:     "Add a name matching the skill directory name.",
+	},
+	codeNameNotString: {
+		Details: "name must be a string. Values such as 123 or true are parsed as other YAML types.",
+		Bad:     "---\nname: 2024\n---",
+		Good:    "---\nname: skill-2024\n---",
+		Fix:     "Use a name made of lowercase letters, digits and hyphens that YAML reads as a string.",
+	},
+	codeNameTooShort: {
+		Details: "name must not be empty.",
+		Bad:     "---\nname: \"\"\n---",
+		Good:    "---\nname: my-skill\n---",
+		Fix:     "Set name to the skill directory name.",
+	},
+	codeNameTooLong: {
+		Details: "name is limited to 64 characters.",
+		Fix:     "Shorten the name, and rename the skill directory to match.",
+	},
+	codeNameInvalidChars: {
+		Details: "name may only contain lowercase letters a-z, digits and hyphens, so it works as a directory name and identifier everywhere.",
+		Bad:     "---\nname: PDF_Tools\n---",
+		Good:    "---\nname: pdf-tools\n---",
+		Fix:     "Lowercase the name and replace other characters with hyphens. `sklint --fix` does this automatically.",
+	},
+	codeNameStartsWithHyphen: {
+		Details: "name must not start with a hyphen.",
+		Bad:     "---\nname: -pdf-tools\n---",
+		Good:    "---\nname: pdf-tools\n---",
+		Fix:     "Remove the leading hyphen. `sklint --fix` does this automatically.",
+	},
+	codeNameEndsWithHyphen: {
+		Details: "name must not end with a hyphen.",
+		Bad:     "---\nname: pdf-tools-\n---",
+		Good:    "---\nname: pdf-tools\n---",
+		Fix:     "Remove the trailing hyphen. `sklint --fix` does this automatically.",
+	},
+	codeNameConsecutiveHyphens: {
+		Details: "name must not contain two hyphens in a row.",
+		Bad:     "---\nname: pdf--tools\n---",
+		Good:    "---\nname: pdf-tools\n---",
+		Fix:     "Collapse the hyphens into one. `sklint --fix` does this automatically.",
+	},
+	codeNameMismatchDirectory: {
+		Details: "name must equal the name of the directory containing SKILL.md. Agents locate skills by directory and refer to them by name, so the two must agree.",
+		Bad:     "pdf_processing/SKILL.md:\n---\nname: pdf-processing\n---",
+		Good:    "pdf-processing/SKILL.md:\n---\nname: pdf-processing\n---",
+		Fix:     "Rename the directory or change the name so they match. `sklint --fix` sets the name to the directory name when that is a valid name.",
+	},
+	codeDescriptionMissing: {
+		Details: "description tells the agent what the skill does and when to use it, and is required.",
+		Bad:     "---\nname: pdf-tools\n---",
+		Good:    "---\nname: pdf-tools\ndescription: Extracts text and tables from PDF files. Use when the user mentions PDFs.\n---",
+		Fix:     "Add a description saying what the skill does and when to use it.",
+	},
+	codeDescriptionNotString: {
+		Details: "description must be a string, not a list or mapping.",
+		Bad:     "---\ndescription:\n  - Extracts text\n---",
+		Good:    "---\ndescription: Extracts text from PDF files.\n---",
+		Fix:     "Write the description as a single string; use a block scalar (>) for long text.",
+	},
+	codeDescriptionTooShort: {
+		Details: "description must not be empty.",
+		Bad:     "---\ndescription: \"\"\n---",
+		Good:    "---\ndescription: Extracts text from PDF files.\n---",
+		Fix:     "Describe what the skill does and when to use it.",
+	},
+	codeDescriptionTooLong: {
+		Details: "description is limited to 1024 characters. It is loaded for every skill up front, so it should stay short.",
+		Fix:     "Shorten the description and move details into the body of SKILL.md.",
+	},
+	codeCompatibilityNotString: {
+		Details: "compatibility, when present, must be a string describing environment requirements.",
+		Bad:     "---\ncompatibility:\n  python: 3.11\n---",
+		Good:    "---\ncompatibility: Requires Python 3.11 and network access.\n---",
+		Fix:     "Write the requirements as a single string.",
+	},
+	codeCompatibilityTooShort: {
+		Details: "compatibility, when present, must not be empty.",
+		Bad:     "---\ncompatibility: \"\"\n---",
+		Fix:     "Describe the requirements or remove the key.",
+	},
+	codeCompatibilityTooLong: {
+		Details: "compatibility is limited to 500 characters.",
+		Fix:     "Shorten it and move details into the body of SKILL.md.",
+	},
+	codeLicenseNotString: {
+		Details: "license, when present, must be a string such as an SPDX identifier or the name of a bundled license file.",
+		Bad:     "---\nlicense:\n  name: MIT\n---",
+		Good:    "---\nlicense: MIT\n---",
+		Fix:     "Write the license as a single string.",
+	},
+	codeLicenseNotAllowed: {
+		Details: "The license expression cannot be complied with using only licenses that the license policy allows. Both sides of AND must be allowed and one side of OR. Versions of a license are compared as one: a deny of GPL-2.0-only also matches GPL-2.0-or-later and GPL-2.0+. A license with a WITH exception passes only if the exception is allowed as well. The policy is set with licenses.allow and licenses.deny in .sklint.yaml; the check is off when neither is set.",
+		Bad:     "licenses: {deny: [GPL-3.0-only]}\n---\nlicense: GPL-3.0-only\n---",
+		Good:    "licenses: {deny: [GPL-3.0-only]}\n---\nlicense: MIT OR GPL-3.0-only\n---",
+		Fix:     "Relicense the skill under an allowed license, or update the policy.",
+	},
+	codeMetadataNotObject: {
+		Details: "metadata, when present, must be a mapping with string keys.",
+		Bad:     "---\nmetadata: v1\n---",
+		Good:    "---\nmetadata:\n  version: \"1.0\"\n---",
+		Fix:     "Turn metadata into key: value pairs.",
+	},
+	codeMetadataValueNotString: {
+		Details: "metadata values must be strings. Unquoted numbers and booleans are parsed as other YAML types.",
+		Bad:     "---\nmetadata:\n  version: 1.0\n  stable: true\n---",
+		Good:    "---\nmetadata:\n  version: \"1.0\"\n  stable: \"true\"\n---",
+		Fix:     "Quote the values. `sklint --fix` quotes plain numbers and booleans automatically.",
+	},
+	codeMetadataKeyMissing: {
+		Details: "The metadata schema declared in .sklint.yaml marks this key as required, but the metadata mapping does not contain it.",
+		Bad:     "---\nmetadata:\n  author: Jane Doe\n---",
+		Good:    "---\nmetadata:\n  author: Jane Doe\n  version: \"1.2.0\"\n---",
+		Fix:     "Add the key to metadata.",
+	},
+	codeMetadataValueInvalid: {
+		Details: "A metadata value does not match the pattern or is not one of the values the metadata schema declares for its key. The pattern must match the whole value, so 1.2.0-beta does not match \\d+\\.\\d+\\.\\d+.",
+		Bad:     "---\nmetadata:\n  version: latest\n---",
+		Good:    "---\nmetadata:\n  version: \"1.2.0\"\n---",
+		Fix:     "Change the value to satisfy the schema reported in the message.",
+	},
+	codeAllowedToolsNotString: {
+		Details: "allowed-tools, when present, must be a space-separated string of tool names.",
+		Bad:     "---\nallowed-tools:\n  - Read\n  - Grep\n---",
+		Good:    "---\nallowed-tools: Read Grep\n---",
+		Fix:     "Join the tool names with spaces.",
+	},
+	codeAllowedToolsEmpty: {
+		Details: "allowed-tools, when present, must list at least one tool.",
+		Bad:     "---\nallowed-tools: \"\"\n---",
+		Good:    "---\nallowed-tools: Read Grep\n---",
+		Fix:     "List the tools or remove the key.",
+	},
+	codeAllowedToolsInvalid: {
+		Details: "Each entry of allowed-tools must be a tool name, optionally followed by an argument pattern in balanced parentheses such as Bash(git status:*). Spaces and commas inside the parentheses belong to the pattern. Invalid entries are reported as warnings and left out of the allowed tools.",
+		Bad:     "---\nallowed-tools: Read Bash(git status:*\n---",
+		Good:    "---\nallowed-tools: Read Bash(git status:*)\n---",
+		Fix:     "Separate tools with spaces and close every parenthesis.",
+	},
+	codeMetadataKeyUnknown: {
+		Details: "The metadata schema sets unknown-keys: reject and this key is not declared in it, which often means a typo of a declared key.",
+		Bad:     "---\nmetadata:\n  auther: Jane Doe\n---",
+		Good:    "---\nmetadata:\n  author: Jane Doe\n---",
+		Fix:     "Fix the key, or declare it in the metadata schema.",
+	},
+	codeLicenseInvalidSPDX: {
+		Details: "license must be an SPDX license expression, such as MIT, Apache-2.0 OR MIT or LicenseRef-Proprietary, or refer to a license file bundled with the skill. Identifiers are checked against the SPDX license list embedded in sklint. A value whose last word looks like a file name, such as LICENSE or terms.txt, must name a file that exists in the skill.",
+		Bad:     "---\nlicense: Apache 2\n---",
+		Good:    "---\nlicense: Apache-2.0\n---\n\n---\nlicense: Complete terms in LICENSE.txt\n--- (with LICENSE.txt in the skill)",
+		Fix:     "Use the SPDX identifier of the license, LicenseRef-<name> for custom licenses, or name the bundled license file.",
+	},
+	codeAllowedToolsDuplicate: {
+		Details: "The same tool, with the same pattern, is listed more than once in allowed-tools. The repetition has no effect and usually means a merge went wrong.",
+		Bad:     "---\nallowed-tools: Read Grep Read\n---",
+		Good:    "---\nallowed-tools: Read Grep\n---",
+		Fix:     "Remove the repeated entry.",
+	},
+	codeAllowedToolsUnknown: {
+		Details: "allowed-tools names a tool that is not in the list of known tools, which is often a typo that silently grants nothing. Tools of MCP servers (mcp__...) are always accepted. The check only runs when the list is configured with known-tools in .sklint.yaml, since hosts name and add tools differently.",
+		Bad:     "---\nallowed-tools: Read Grepp\n---",
+		Good:    "---\nallowed-tools: Read Grep\n---",
+		Fix:     "Fix the tool name, or add the tool to known-tools if your host provides it.",
+	},
+	codeAllowedToolsWhitespace: {
+		Details: "The entries of allowed-tools are separated by commas, tabs, line breaks or several spaces, or the value has leading or trailing whitespace. Hosts that split on single spaces may read empty entries or keep the commas in tool names.",
+		Bad:     "---\nallowed-tools: \"Read   Grep \"\n---",
+		Good:    "---\nallowed-tools: \"Read Grep\"\n---",
+		Fix:     "Separate the entries with single spaces. `sklint --fix` does this automatically.",
+	},
+	codeSkillMDSymlink: {
+		Details: "SKILL.md is a symlink. This works locally but may break when the skill is copied or packaged.",
+		Fix:     "Replace the symlink with a regular file, or turn this code off if links are intended.",
+	},
+	codeSkillMDTooLongLines: {
+		Details: "SKILL.md is longer than 500 lines. The whole file is loaded when the skill is used, so long files waste context.",
+		Fix:     "Move detailed material into files under references/ and link to them from SKILL.md.",
+	},
+	codeSkillMDMissingBody: {
+		Details: "SKILL.md has frontmatter but no instructions after it.",
+		Bad:     "---\nname: pdf-tools\ndescription: Extracts text from PDF files.\n---",
+		Good:    "---\nname: pdf-tools\ndescription: Extracts text from PDF files.\n---\n# PDF Tools\n\nRun scripts/extract.py on the file.",
+		Fix:     "Add the instructions the agent should follow when using the skill.",
+	},
+	codeUnknownTopLevelKey: {
+		Details: "The frontmatter has a key the specification does not define. Agents ignore it, and it is often a typo of a known key.",
+		Bad:     "---\nname: pdf-tools\ndescripton: Extracts text from PDF files.\n---",
+		Good:    "---\nname: pdf-tools\ndescription: Extracts text from PDF files.\n---",
+		Fix:     "Fix the typo, move custom data under metadata, or suppress the finding with # sklint-disable UNKNOWN_TOP_LEVEL_KEY.",
+	},
+	codeScriptsDirEmpty: {
+		Details: "The scripts directory exists but is empty.",
+		Fix:     "Add the scripts or remove the directory.",
+	},
+	codeReferencesDirEmpty: {
+		Details: "The references directory exists but is empty.",
+		Fix:     "Add the reference documents or remove the directory.",
+	},
+	codeAssetsDirEmpty: {
+		Details: "The assets directory exists but is empty.",
+		Fix:     "Add the assets or remove the directory.",
+	},
+	codeRefContainsDotDot: {
+		Details: "A file reference in SKILL.md contains '..'. Skills should be self-contained and only refer to their own files.",
+		Bad:     "See [the guide](../shared/guide.md).",
+		Good:    "See [the guide](references/guide.md).",
+		Fix:     "Copy the file into the skill and refer to it relative to the skill directory.",
+	},
+	codeRefTooDeep: {
+		Details: "A file reference is nested more than one directory deep. Keep references one level below the skill directory so agents find them easily.",
+		Bad:     "See [the guide](references/pdf/forms/guide.md).",
+		Good:    "See [the guide](references/pdf-forms.md).",
+		Fix:     "Flatten the directory structure of referenced files.",
+	},
+	codeRefMissingFile: {
+		Details: "A file referenced from SKILL.md does not exist, or is a symlink that cannot be resolved.",
+		Bad:     "Run [setup](scripts/setup.sh). (no scripts/setup.sh in the skill)",
+		Good:    "Run [setup](scripts/setup.sh). (scripts/setup.sh exists)",
+		Fix:     "Add the file, or correct the path in SKILL.md.",
+	},
+	codeRefEscapesRoot: {
+		Details: "A file reference resolves outside the skill directory, directly or through a symlink.",
+		Fix:     "Copy the file into the skill and refer to it relative to the skill directory.",
+	},
+	codeYAMLAnchor: {
+		Details: "Portability rule, enabled with the portability option. Anchors (&name) mark a node for reuse by aliases. Hosts with minimal YAML parsers may keep the anchor in the value or fail to parse the frontmatter.",
+		Bad:     "---\nmetadata: &meta\n  owner: docs\n---",
+		Good:    "---\nmetadata:\n  owner: docs\n---",
+		Fix:     "Remove the anchor and write values out in full.",
+	},
+	codeYAMLAlias: {
+		Details: "Portability rule, enabled with the portability option. Aliases (*name) repeat an anchored node. Hosts that do not resolve them read the literal alias text instead of the value.",
+		Bad:     "---\nname: &name pdf-tools\ndescription: *name\n---",
+		Good:    "---\nname: pdf-tools\ndescription: Extracts text from PDF files.\n---",
+		Fix:     "Replace the alias with the value it refers to.",
+	},
+	codeYAMLExplicitTag: {
+		Details: "Portability rule, enabled with the portability option. Explicit tags such as !!binary or !!str change how a value is typed. Simpler parsers ignore them or reject the frontmatter.",
+		Bad:     "---\nmetadata:\n  version: !!str 1.0\n---",
+		Good:    "---\nmetadata:\n  version: \"1.0\"\n---",
+		Fix:     "Drop the tag; quote values that must be strings.",
+	},
+	codeYAMLMergeKey: {
+		Details: "Portability rule, enabled with the portability option. The merge key << copies the entries of another mapping. It is a YAML 1.1 extension, so other parsers read it as a key named \"<<\".",
+		Bad:     "---\nmetadata:\n  <<: *defaults\n  owner: docs\n---",
+		Good:    "---\nmetadata:\n  team: platform\n  owner: docs\n---",
+		Fix:     "Write the merged entries out in the mapping.",
+	},
+	codeYAML11Boolean: {
+		Details: "Portability rule, enabled with the portability option. YAML 1.2 reads unquoted yes, no, on, off, y and n as strings, but YAML 1.1 parsers read them as booleans, so a string field may turn into true or false on some hosts.",
+		Bad:     "---\nmetadata:\n  experimental: yes\n---",
+		Good:    "---\nmetadata:\n  experimental: \"yes\"\n---",
+		Fix:     "Quote the value.",
+	},
+	codeYAMLMultipleDocuments: {
+		Details: "Portability rule, enabled with the portability option. A line starting with '--- ' or '...' inside the frontmatter starts or ends a YAML document. yaml.v3 reads only the first document; other hosts read another one or fail.",
+		Bad:     "---\nname: pdf-tools\n...\ndescription: Extracts text.\n---",
+		Good:    "---\nname: pdf-tools\ndescription: Extracts text.\n---",
+		Fix:     "Remove the document marker so the frontmatter is a single document.",
+	},
+	codeSuppressionUnused: {
+		Details: "An inline sklint-disable comment matches no finding, usually because the problem was fixed or the code is misspelled. A frontmatter comment that lists no codes is reported too, since it would otherwise hide every finding of the frontmatter.",
+		Bad:     "<!-- sklint-disable-next-line REF_MISSING_FILE -->\nRun [setup](scripts/setup.sh). (the file exists)",
+		Good:    "Run [setup](scripts/setup.sh).",
+		Fix:     "Remove the comment or correct the code it names.",
+	},
+}
@@ -0,0 +1,348 @@
+package validator
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FixResult is the outcome of FixSkill.
+type FixResult struct {
+	// File is the SKILL.md that was read; empty when the skill could not be
+	// parsed far enough to be fixed.
+	File     string
+	Original []byte
+	Fixed    []byte
+	// Fixes lists the findings repaired by the edits.
+	Fixes []Finding
+}
+
+// Changed reports whether fixing modified SKILL.md.
+func (r FixResult) Changed() bool {
+	return !bytes.Equal(r.Original, r.Fixed)
+}
+
+// FixSkill validates the skill at path and returns its SKILL.md with every
+// fixable, unsuppressed finding repaired. Only the affected YAML scalars are
+// rewritten; nothing is written to disk.
+func FixSkill(path string, opts Options) (FixResult, error) {
+	result, src, err := validateSkill(path, nil, opts)
+	if err != nil || src == nil {
+		return FixResult{}, err
+	}
+	fixed := FixResult{File: src.file, Original: src.content, Fixed: src.content}
+
+	plan := planFixes(src)
+	edits := make([]textEdit, 0)
+	seen := make(map[int]bool)
+	for _, finding := range append(result.Errors, result.Warnings...) {
+		if !finding.Fixable {
+			continue
+		}
+		fixed.Fixes = append(fixed.Fixes, finding)
+		for _, edit := range plan.edits(finding) {
+			if !seen[edit.start] {
+				seen[edit.start] = true
+				edits = append(edits, edit)
+			}
+		}
+	}
+	fixed.Fixed = applyEdits(src.content, edits)
+	return fixed, nil
+}
+
+// textEdit replaces content[start:end] with text.
+type textEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+var nameFixCodes = map[string]bool{
+	codeNameInvalidChars:       true,
+	codeNameStartsWithHyphen:   true,
+	codeNameEndsWithHyphen:     true,
+	codeNameConsecutiveHyphens: true,
+	codeNameMismatchDirectory:  true,
+}
+
+// fixPlan holds the edits that would repair the fixable findings of a skill.
+type fixPlan struct {
+	name           *textEdit
+	nameMatchesDir bool
+	metadata       []textEdit
+	tools          *textEdit
+}
+
+func (p fixPlan) edits(finding Finding) []textEdit {
+	switch {
+	case nameFixCodes[finding.Code] && p.name != nil:
+		if finding.Code == codeNameMismatchDirectory && !p.nameMatchesDir {
+			return nil
+		}
+		return []textEdit{*p.name}
+	case finding.Code == codeMetadataValueNotString:
+		return p.metadata
+	case finding.Code == codeAllowedToolsWhitespace && p.tools != nil:
+		return []textEdit{*p.tools}
+	}
+	return nil
+}
+
+// markFixable flags the findings the plan can repair and attaches its edits
+// to them as suggestions.
+func markFixable(result *Result, plan fixPlan, lines lineIndex) {
+	for _, list := range [][]Finding{result.Errors, result.Warnings} {
+		for i := range list {
+			edits := plan.edits(list[i])
+			list[i].Fixable = len(edits) > 0
+			list[i].Suggestions = suggestions(list[i].File, lines, edits)
+		}
+	}
+}
+
+func suggestions(file string, lines lineIndex, edits []textEdit) []Suggestion {
+	if len(edits) == 0 {
+		return nil
+	}
+	list := make([]Suggestion, 0, len(edits))
+	for _, edit := range edits {
+		line, column := lines.position(edit.start)
+		endLine, endColumn := lines.position(edit.end)
+		list = append(list, Suggestion{
+			File:      file,
+			Start:     edit.start,
+			End:       edit.end,
+			Line:      line,
+			Column:    column,
+			EndLine:   endLine,
+			EndColumn: endColumn,
+			NewText:   edit.text,
+		})
+	}
+	return list
+}
+
+func planFixes(src *skillSource) fixPlan {
+	lines := newLineIndex(src.content)
+	var plan fixPlan
+	plan.name, plan.nameMatchesDir = nameEdit(src, lines)
+	plan.metadata = metadataEdits(src, lines)
+	plan.tools = toolsEdit(src, lines)
+	return plan
+}
+
+// nameEdit replaces the name with the directory name when that is a valid
+// name, and with a normalized form of the current name otherwise.
+func nameEdit(src *skillSource, lines lineIndex) (*textEdit, bool) {
+	node := mappingValue(src.root, "name")
+	if node == nil || node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		return nil, false
+	}
+	desired := normalizeName(node.Value)
+	if isValidName(src.dirName) {
+		desired = src.dirName
+	}
+	if desired == node.Value || !isValidName(desired) {
+		return nil, false
+	}
+	start, end, ok := lines.scalarSpan(src.frontmatter.YAMLStartLine, node)
+	if !ok {
+		return nil, false
+	}
+	return &textEdit{start: start, end: end, text: requote(node.Style, desired)}, desired == src.dirName
+}
+
+// metadataEdits quotes plain numeric and boolean metadata values. It returns
+// nothing unless every non-string value can be fixed that way.
+func metadataEdits(src *skillSource, lines lineIndex) []textEdit {
+	node := mappingValue(src.root, "metadata")
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	edits := make([]textEdit, 0)
+	for i := 1; i < len(node.Content); i += 2 {
+		value := node.Content[i]
+		if value.Kind == yaml.ScalarNode && value.Tag == "!!str" {
+			continue
+		}
+		if value.Kind != yaml.ScalarNode || !isQuotableTag(value.Tag) || value.Style != 0 {
+			return nil
+		}
+		start, end, ok := lines.scalarSpan(src.frontmatter.YAMLStartLine, value)
+		if !ok {
+			return nil
+		}
+		edits = append(edits, textEdit{start: start, end: end, text: requote(yaml.DoubleQuotedStyle, value.Value)})
+	}
+	return edits
+}
+
+// toolsEdit rewrites allowed-tools with its entries separated by single
+// spaces.
+func toolsEdit(src *skillSource, lines lineIndex) *textEdit {
+	node := mappingValue(src.root, "allowed-tools")
+	if node == nil || node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		return nil
+	}
+	normalized := normalizeTools(node.Value)
+	if normalized == node.Value || normalized == "" {
+		return nil
+	}
+	if node.Style == yaml.DoubleQuotedStyle && strings.ContainsAny(normalized, `"\`) ||
+		node.Style == yaml.SingleQuotedStyle && strings.Contains(normalized, "'") {
+		return nil
+	}
+	start, end, ok := lines.scalarSpan(src.frontmatter.YAMLStartLine, node)
+	if !ok {
+		return nil
+	}
+	return &textEdit{start: start, end: end, text: requote(node.Style, normalized)}
+}
+
+// normalizeTools joins the entries of an allowed-tools value with single
+// spaces, keeping the whitespace inside argument patterns.
+func normalizeTools(value string) string {
+	entries := make([]string, 0)
+	for _, token := range parseAllowedTools(value) {
+		entries = append(entries, token.text)
+	}
+	return strings.Join(entries, " ")
+}
+
+// frontmatterStartEdit inserts the missing opening delimiter when SKILL.md
+// starts with a YAML mapping that is closed by a delimiter, or repairs an
+// opening delimiter with trailing whitespace.
+func frontmatterStartEdit(lines lineIndex) *textEdit {
+	first, start, ok := lines.line(1)
+	if !ok {
+		return nil
+	}
+	if strings.TrimSpace(first) == "---" {
+		return &textEdit{start: start, end: start + len(first), text: "---"}
+	}
+	for n := 2; ; n++ {
+		text, lineStart, ok := lines.line(n)
+		if !ok {
+			return nil
+		}
+		if text == "---" {
+			if !isYAMLMapping(lines.content[start:lineStart]) {
+				return nil
+			}
+			return &textEdit{start: start, end: start, text: "---\n"}
+		}
+	}
+}
+
+// frontmatterEndEdit inserts the missing closing delimiter before the first
+// blank line or Markdown heading after the opening delimiter, provided the
+// lines in between are a YAML mapping.
+func frontmatterEndEdit(lines lineIndex) *textEdit {
+	_, yamlStart, ok := lines.line(2)
+	if !ok {
+		return nil
+	}
+	for n := 2; ; n++ {
+		text, lineStart, ok := lines.line(n)
+		if !ok {
+			if !isYAMLMapping(lines.content[yamlStart:]) {
+				return nil
+			}
+			insert := "---\n"
+			if !bytes.HasSuffix(lines.content, []byte("\n")) {
+				insert = "\n" + insert
+			}
+			return &textEdit{start: len(lines.content), end: len(lines.content), text: insert}
+		}
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "# ") || strings.HasPrefix(text, "##") {
+			if !isYAMLMapping(lines.content[yamlStart:lineStart]) {
+				return nil
+			}
+			return &textEdit{start: lineStart, end: lineStart, text: "---\n"}
+		}
+	}
+}
+
+func isYAMLMapping(text []byte) bool {
+	var node yaml.Node
+	if err := yaml.Unmarshal(text, &node); err != nil {
+		return false
+	}
+	root, err := mappingRoot(&node)
+	return err == nil && len(root.Content) > 0
+}
+
+func isQuotableTag(tag string) bool {
+	return tag == "!!int" || tag == "!!float" || tag == "!!bool"
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Kind == yaml.ScalarNode && node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func isValidName(name string) bool {
+	return len(name) >= 1 && len(name) <= 64 && namePattern.MatchString(name) &&
+		!strings.HasPrefix(name, "-") && !strings.HasSuffix(name, "-") && !strings.Contains(name, "--")
+}
+
+// normalizeName lowercases name and turns every run of other characters into
+// a single hyphen.
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	normalized := b.String()
+	for strings.Contains(normalized, "--") {
+		normalized = strings.ReplaceAll(normalized, "--", "-")
+	}
+	return strings.Trim(normalized, "-")
+}
+
+func requote(style yaml.Style, value string) string {
+	switch style {
+	case yaml.DoubleQuotedStyle:
+		return `"` + value + `"`
+	case yaml.SingleQuotedStyle:
+		return "'" + value + "'"
+	}
+	return value
+}
+
+func applyEdits(content []byte, edits []textEdit) []byte {
+	if len(edits) == 0 {
+		return content
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var b bytes.Buffer
+	last := 0
+	for _, edit := range edits {
+		if edit.start < last {
+			continue
+		}
+		b.Write(content[last:edit.start])
+		b.WriteString(edit.text)
+		last = edit.end
+	}
+	b.Write(content[last:])
+	return b.Bytes()
+}
+
+func optionalEdit(edit *textEdit) []textEdit {
+	if edit == nil {
+		return nil
+	}
+	return []textEdit{*edit}
+}
@@ -0,0 +1,201 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeSkill(t *testing.T, dirName, content string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), dirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return dir
+}
+
+func TestFixSkill(t *testing.T) {
+	dir := writeSkill(t, "pdf-tools", "---\n"+
+		"name: \"PDF_Tools\" # display name\n"+
+		"description: Works with PDFs.\n"+
+		"metadata:\n"+
+		"  version: 1.0\n"+
+		"  stable: true\n"+
+		"  author: Jane\n"+
+		"---\n"+
+		"Body\n")
+
+	fixed, err := FixSkill(dir, Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "---\n" +
+		"name: \"pdf-tools\" # display name\n" +
+		"description: Works with PDFs.\n" +
+		"metadata:\n" +
+		"  version: \"1.0\"\n" +
+		"  stable: \"true\"\n" +
+		"  author: Jane\n" +
+		"---\n" +
+		"Body\n"
+	if string(fixed.Fixed) != want {
+		t.Fatalf("unexpected fixed content:\n%s", fixed.Fixed)
+	}
+	if !fixed.Changed() || len(fixed.Fixes) == 0 {
+		t.Fatalf("expected fixes to be reported: %#v", fixed)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), fixed.Fixed, 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	result, err := ValidateSkill(dir, Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Valid || len(result.Errors) != 0 {
+		t.Fatalf("expected fixed skill to be valid: %#v", result)
+	}
+}
+
+func TestFixNormalizesNameWhenDirectoryNameIsInvalid(t *testing.T) {
+	dir := writeSkill(t, "Bad_Dir", "---\nname: -My--Skill-\ndescription: d\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, finding := range result.Errors {
+		want := finding.Code != codeNameMismatchDirectory
+		if finding.Fixable != want {
+			t.Fatalf("unexpected fixable=%v for %s", finding.Fixable, finding.Code)
+		}
+	}
+
+	fixed, err := FixSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(fixed.Fixed) != "---\nname: my-skill\ndescription: d\n---\nBody\n" {
+		t.Fatalf("unexpected fixed content:\n%s", fixed.Fixed)
+	}
+}
+
+func TestNestedMetadataIsNotFixable(t *testing.T) {
+	dir := writeSkill(t, "nested", "---\nname: nested\ndescription: d\nmetadata:\n  count: 1\n  tags: [a, b]\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codeMetadataValueNotString)
+	for _, finding := range result.Errors {
+		if finding.Fixable {
+			t.Fatalf("expected %s not to be fixable", finding.Code)
+		}
+	}
+	fixed, err := FixSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fixed.Changed() {
+		t.Fatalf("expected no changes, got:\n%s", fixed.Fixed)
+	}
+}
+
+// applySuggestions applies the suggestions of findings to content.
+func applySuggestions(content string, findings []Finding) string {
+	edits := make([]textEdit, 0)
+	for _, finding := range findings {
+		for _, s := range finding.Suggestions {
+			edits = append(edits, textEdit{start: s.Start, end: s.End, text: s.NewText})
+		}
+	}
+	return string(applyEdits([]byte(content), edits))
+}
+
+func TestSuggestions(t *testing.T) {
+	content := "---\nname: other-name\ndescription: d\n---\nBody\n"
+	result, err := ValidateContent(writeSkill(t, "my-skill", content), []byte(content), Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codeNameMismatchDirectory)
+	want := []Suggestion{{File: "SKILL.md", Start: 10, End: 20, Line: 2, Column: 7, EndLine: 2, EndColumn: 17, NewText: "my-skill"}}
+	if got := result.Errors[0].Suggestions; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
+
+func TestSuggestionsResolveFindings(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		code    string
+		fixed   string
+	}{
+		{
+			"tools-whitespace",
+			"---\nname: my-skill\ndescription: d\nallowed-tools: \"Read   Bash(git status:*)\\tGrep \"\n---\nBody\n",
+			codeAllowedToolsWhitespace,
+			"---\nname: my-skill\ndescription: d\nallowed-tools: \"Read Bash(git status:*) Grep\"\n---\nBody\n",
+		},
+		{
+			"tools-commas",
+			"---\nname: my-skill\ndescription: d\nallowed-tools: Read, Grep, Bash(git add a,b)\n---\nBody\n",
+			codeAllowedToolsWhitespace,
+			"---\nname: my-skill\ndescription: d\nallowed-tools: Read Grep Bash(git add a,b)\n---\nBody\n",
+		},
+		{
+			"missing-start",
+			"name: my-skill\ndescription: d\n---\nBody\n",
+			codeFrontmatterStart,
+			"---\nname: my-skill\ndescription: d\n---\nBody\n",
+		},
+		{
+			"start-with-trailing-space",
+			"--- \nname: my-skill\ndescription: d\n---\nBody\n",
+			codeFrontmatterStart,
+			"---\nname: my-skill\ndescription: d\n---\nBody\n",
+		},
+		{
+			"missing-end",
+			"---\nname: my-skill\ndescription: d\n\n# Title\n",
+			codeFrontmatterEnd,
+			"---\nname: my-skill\ndescription: d\n---\n\n# Title\n",
+		},
+	}
+	for _, tc := range cases {
+		dir := writeSkill(t, "my-skill", tc.content)
+		result, err := ValidateSkill(dir, Options{})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		findings := append(result.Errors, result.Warnings...)
+		if len(findings) != 1 || findings[0].Code != tc.code || len(findings[0].Suggestions) != 1 {
+			t.Fatalf("%s: expected one %s finding with a suggestion, got %#v", tc.name, tc.code, findings)
+		}
+		fixed := applySuggestions(tc.content, findings)
+		if fixed != tc.fixed {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.fixed, fixed)
+		}
+		result, err = ValidateContent(dir, []byte(fixed), Options{})
+		if err != nil || !result.Valid || len(result.Warnings) != 0 {
+			t.Fatalf("%s: expected the suggestion to resolve the finding, got %#v, %v", tc.name, result, err)
+		}
+	}
+}
+
+func TestNoSuggestionForFreeText(t *testing.T) {
+	dir := writeSkill(t, "my-skill", "# Just Markdown\n\nNo frontmatter here.\n")
+	result, err := ValidateSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codeFrontmatterStart)
+	if len(result.Errors[0].Suggestions) != 0 {
+		t.Fatalf("expected no suggestion, got %#v", result.Errors[0].Suggestions)
+	}
+}
@@ -0,0 +1,133 @@
+package validator
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// maxLinkHops bounds symlink chains so that cycles are reported as
+// unresolvable instead of looping forever.
+const maxLinkHops = 40
+
+// ReadLinkFS is implemented by file systems that expose symbolic links. Its
+// methods match fs.ReadLinkFS from Go 1.25. ValidateFS reports symlinks only
+// on such file systems; on others, links are whatever the file system makes
+// of them.
+type ReadLinkFS interface {
+	fs.FS
+	// ReadLink returns the destination of the named symbolic link.
+	ReadLink(name string) (string, error)
+	// Lstat returns information about the named file without following a
+	// final symbolic link.
+	Lstat(name string) (fs.FileInfo, error)
+}
+
+// osFS is the file system ValidateSkill validates through: os.DirFS with
+// symlink support. Link destinations that resolve inside dir are rewritten
+// relative to the link, so links by absolute path or by a detour through
+// the parent directory are judged by where they actually lead.
+type osFS struct {
+	fs.FS
+	dir string
+}
+
+func newOSFS(dir string) osFS {
+	return osFS{FS: os.DirFS(dir), dir: dir}
+}
+
+func (f osFS) path(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return filepath.Join(f.dir, filepath.FromSlash(name)), nil
+}
+
+func (f osFS) Stat(name string) (fs.FileInfo, error) {
+	full, err := f.path("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Stat(full)
+}
+
+func (f osFS) Lstat(name string) (fs.FileInfo, error) {
+	full, err := f.path("lstat", name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Lstat(full)
+}
+
+func (f osFS) ReadLink(name string) (string, error) {
+	full, err := f.path("readlink", name)
+	if err != nil {
+		return "", err
+	}
+	dest, err := os.Readlink(full)
+	if err != nil {
+		return "", err
+	}
+	linkDir := filepath.Dir(full)
+	target := dest
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(linkDir, target)
+	}
+	if rel, err := filepath.Rel(f.dir, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return dest, nil
+	}
+	rel, err := filepath.Rel(linkDir, target)
+	if err != nil {
+		return dest, nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// lstat is fs.Stat that does not follow a final symlink when fsys can tell.
+func lstat(fsys fs.FS, name string) (fs.FileInfo, error) {
+	if links, ok := fsys.(ReadLinkFS); ok {
+		return links.Lstat(name)
+	}
+	return fs.Stat(fsys, name)
+}
+
+var errTooManyLinks = errors.New("too many levels of symbolic links")
+
+// resolveLink follows the symlink chain starting at name. It returns the
+// final path within fsys, or escapes=true as soon as a link leads outside
+// root, in which case fsys alone cannot tell where it ends.
+func resolveLink(fsys ReadLinkFS, root, name string) (resolved string, escapes bool, err error) {
+	for hops := 0; hops < maxLinkHops; hops++ {
+		dest, err := fsys.ReadLink(name)
+		if err != nil {
+			return "", false, err
+		}
+		if path.IsAbs(dest) || filepath.IsAbs(dest) {
+			return "", true, nil
+		}
+		name = path.Join(path.Dir(name), filepath.ToSlash(dest))
+		if !isWithinRoot(root, name) {
+			return "", true, nil
+		}
+		info, err := fsys.Lstat(name)
+		if err != nil {
+			return "", false, err
+		}
+		if info.Mode()&fs.ModeSymlink == 0 {
+			return name, false, nil
+		}
+	}
+	return "", false, errTooManyLinks
+}
+
+// isWithinRoot reports whether the slash-separated, clean path name lies
+// within root.
+func isWithinRoot(root, name string) bool {
+	if root == "." {
+		return name != ".." && !strings.HasPrefix(name, "../")
+	}
+	return name == root || strings.HasPrefix(name, root+"/")
+}
@@ -0,0 +1,215 @@
+package validator
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidateFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"skills/pdf-tools/SKILL.md": {Data: []byte("---\n" +
+			"name: pdf-tools\n" +
+			"description: Works with PDFs.\n" +
+			"---\n" +
+			"See [the guide](references/guide.md) and [setup](scripts/setup.sh).\n")},
+		"skills/pdf-tools/references/guide.md": {Data: []byte("# Guide\n")},
+		"skills/pdf-tools/assets":              {Mode: os.ModeDir},
+	}
+
+	result, err := ValidateFS(fsys, "skills/pdf-tools", Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Path != "skills/pdf-tools" || !result.Valid {
+		t.Fatalf("expected valid result, got %#v", result)
+	}
+	assertFinding(t, result, LevelWarning, codeAssetsDirEmpty)
+	assertFinding(t, result, LevelWarning, codeRefMissingFile)
+	if len(result.Warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %#v", result.Warnings)
+	}
+}
+
+func TestValidateFSRootErrors(t *testing.T) {
+	fsys := fstest.MapFS{"file": {Data: []byte("x")}}
+	cases := []struct {
+		root string
+		code string
+	}{
+		{root: "missing", code: codePathNotFound},
+		{root: "file", code: codePathNotDirectory},
+		{root: ".", code: codeSkillMDMissing},
+	}
+	for _, tc := range cases {
+		result, err := ValidateFS(fsys, tc.root, Options{})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.root, err)
+		}
+		assertFinding(t, result, LevelError, tc.code)
+	}
+
+	if _, err := ValidateFS(fsys, "../outside", Options{}); err == nil {
+		t.Fatal("expected error for invalid root")
+	}
+}
+
+func TestValidateFSRootDirectoryNameIsUnknown(t *testing.T) {
+	fsys := fstest.MapFS{
+		"SKILL.md": {Data: []byte("---\nname: anything\ndescription: Lives at the root.\n---\nBody\n")},
+	}
+	result, err := ValidateFS(fsys, ".", Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Valid || len(result.Errors) != 0 {
+		t.Fatalf("expected valid result, got %#v", result)
+	}
+}
+
+func symlinkOrSkip(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+}
+
+func TestSkillMDSymlinks(t *testing.T) {
+	body := "---\nname: my-skill\ndescription: Linked.\n---\nBody\n"
+	cases := []struct {
+		name   string
+		target func(dir string) string
+		follow bool
+		code   string
+		level  FindingLevel
+	}{
+		{name: "inside", target: func(dir string) string { return "docs/SKILL.md" }, code: codeSkillMDSymlink, level: LevelWarning},
+		{name: "absolute inside", target: func(dir string) string { return filepath.Join(dir, "docs", "SKILL.md") }, code: codeSkillMDSymlink, level: LevelWarning},
+		{name: "outside", target: func(dir string) string { return "../shared.md" }, code: codeSkillMDSymlinkEscapes, level: LevelError},
+		{name: "outside followed", target: func(dir string) string { return "../shared.md" }, follow: true, code: codeSkillMDSymlink, level: LevelWarning},
+		{name: "dangling", target: func(dir string) string { return "docs/missing.md" }, code: codeSkillMDSymlinkInvalid, level: LevelError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parent := t.TempDir()
+			dir := filepath.Join(parent, "my-skill")
+			if err := os.MkdirAll(filepath.Join(dir, "docs"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			for _, file := range []string{filepath.Join(dir, "docs", "SKILL.md"), filepath.Join(parent, "shared.md")} {
+				if err := os.WriteFile(file, []byte(body), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			symlinkOrSkip(t, tc.target(dir), filepath.Join(dir, "SKILL.md"))
+
+			result, err := ValidateSkill(dir, Options{FollowSymlinks: tc.follow})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertFinding(t, result, tc.level, tc.code)
+			if tc.level == LevelWarning && !result.Valid {
+				t.Fatalf("expected valid result, got %#v", result)
+			}
+		})
+	}
+}
+
+func TestReferenceSymlinks(t *testing.T) {
+	elsewhere := t.TempDir()
+	dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Linked.\n---\n"+
+		"See [inside](references/inside.md), [outside](references/outside.md) and [dangling](references/dangling.md).\n")
+	refs := filepath.Join(dir, "references")
+	if err := os.MkdirAll(refs, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(refs, "guide.md"), []byte("# Guide\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(elsewhere, "outside.md"), []byte("# Outside\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	symlinkOrSkip(t, "guide.md", filepath.Join(refs, "inside.md"))
+	symlinkOrSkip(t, filepath.Join(elsewhere, "outside.md"), filepath.Join(refs, "outside.md"))
+	symlinkOrSkip(t, "nowhere.md", filepath.Join(refs, "dangling.md"))
+
+	result, err := ValidateSkill(dir, Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	codes := make(map[string]string)
+	for _, finding := range result.Warnings {
+		codes[finding.Message] = finding.Code
+	}
+	want := map[string]string{
+		"Reference 'references/outside.md' resolves outside the skill directory.": codeRefEscapesRoot,
+		"Reference 'references/dangling.md' could not be resolved.":               codeRefMissingFile,
+	}
+	if len(codes) != len(want) {
+		t.Fatalf("expected %v, got %#v", want, result.Warnings)
+	}
+	for message, code := range want {
+		if codes[message] != code {
+			t.Fatalf("expected %s for %q, got %#v", code, message, result.Warnings)
+		}
+	}
+}
+
+// zipFS returns a zip.Reader over files; entries whose mode has
+// fs.ModeSymlink set store the link target as their content.
+func zipFS(t *testing.T, files map[string]string, modes map[string]fs.FileMode) *zip.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate}
+		header.SetMode(0o644)
+		if mode, ok := modes[name]; ok {
+			header.SetMode(mode)
+		}
+		f, err := w.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestValidateFSSymlinksWithoutReadLink(t *testing.T) {
+	link := fs.ModeSymlink | 0o777
+	skillLink := zipFS(t, map[string]string{
+		"s/SKILL.md":  "target.md",
+		"s/target.md": "---\nname: s\ndescription: Linked.\n---\nBody\n",
+	}, map[string]fs.FileMode{"s/SKILL.md": link})
+	result, err := ValidateFS(skillLink, "s", Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codeSkillMDSymlinkInvalid)
+
+	refLink := zipFS(t, map[string]string{
+		"s/SKILL.md":            "---\nname: s\ndescription: Linked.\n---\nSee [guide](references/guide.md).\n",
+		"s/references/guide.md": "other.md",
+	}, map[string]fs.FileMode{"s/references/guide.md": link})
+	result, err = ValidateFS(refLink, "s", Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Message != "Reference 'references/guide.md' could not be resolved." {
+		t.Fatalf("expected unresolvable reference, got %#v", result.Warnings)
+	}
+}
@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/sven1103-agent/sklint/internal/spdx"
+)
+
+// licenseFilePattern matches the last word of license values that refer to
+// a file bundled with the skill, as in "LICENSE" or "Complete terms in
+// LICENSE.txt".
+var licenseFilePattern = regexp.MustCompile(`^(?:\./)?(?:[\w.-]+/)*(?:(?i:licen[cs]e|copying|notice|eula)[\w.-]*|[\w.-]+\.(?:txt|md))$`)
+
+// licenseFile returns the file the license value refers to, or "".
+func licenseFile(value string) string {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return ""
+	}
+	file := strings.TrimRight(fields[len(fields)-1], ".,;:)")
+	if !licenseFilePattern.MatchString(file) {
+		return ""
+	}
+	return strings.TrimPrefix(file, "./")
+}
+
+var licenseChecks = map[string]func(ctx *SkillContext) []Finding{
+	codeLicenseInvalidSPDX: func(ctx *SkillContext) []Finding {
+		value, ok := ctx.Frontmatter["license"].(string)
+		if !ok {
+			return nil
+		}
+		_, err := spdx.Parse(value)
+		if err == nil {
+			return nil
+		}
+		if file := licenseFile(value); file != "" {
+			if !ctx.Options.CheckRefsExist || bundledFile(ctx, file) {
+				return nil
+			}
+			return []Finding{ctx.ValueFinding("license", fmt.Sprintf("Frontmatter 'license' refers to '%s', which does not exist in the skill.", file))}
+		}
+		return []Finding{ctx.ValueFinding("license", fmt.Sprintf("Frontmatter 'license' is not a valid SPDX expression: %s.", err))}
+	},
+	codeLicenseNotAllowed: func(ctx *SkillContext) []Finding {
+		value, ok := ctx.Frontmatter["license"].(string)
+		if !ok || (len(ctx.Options.AllowedLicenses) == 0 && len(ctx.Options.DeniedLicenses) == 0) {
+			return nil
+		}
+		expr, err := spdx.Parse(value)
+		if err != nil || expr.Satisfied(func(leaf *spdx.Expression) bool { return licenseAllowed(leaf, ctx.Options) }) {
+			return nil
+		}
+		return []Finding{ctx.ValueFinding("license", fmt.Sprintf("License '%s' is not allowed by the license policy.", value))}
+	},
+}
+
+// bundledFile reports whether name is a regular file inside the skill.
+func bundledFile(ctx *SkillContext, name string) bool {
+	target := path.Join(ctx.Dir, name)
+	if !isWithinRoot(ctx.Dir, target) {
+		return false
+	}
+	info, err := fs.Stat(ctx.FS, target)
+	return err == nil && !info.IsDir()
+}
+
+// licenseAllowed applies the allow and deny lists of opts to a license of an
+// expression and to its WITH exception, if any. Identifiers are compared by
+// spdx.Family, so a deny of GPL-2.0-only also catches GPL-2.0-or-later and
+// GPL-2.0+. An empty allow list allows every identifier that is not denied.
+func licenseAllowed(leaf *spdx.Expression, opts Options) bool {
+	ids := []string{leaf.License}
+	if leaf.Exception != "" {
+		ids = append(ids, leaf.Exception)
+	}
+	for _, id := range ids {
+		if listed(opts.DeniedLicenses, id) || (len(opts.AllowedLicenses) > 0 && !listed(opts.AllowedLicenses, id)) {
+			return false
+		}
+	}
+	return true
+}
+
+// listed reports whether a license list names the family of id.
+func listed(list []string, id string) bool {
+	for _, entry := range list {
+		if spdx.Family(entry) == spdx.Family(id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLicense(t *testing.T) {
+	cases := []struct {
+		license string
+		file    string
+		code    string
+	}{
+		{"MIT", "", ""},
+		{"Apache-2.0 OR MIT", "", ""},
+		{"LicenseRef-Proprietary", "", ""},
+		{"Complete terms in LICENSE.txt", "LICENSE.txt", ""},
+		{"LICENSE", "", codeLicenseInvalidSPDX},
+		{"Apache 2", "", codeLicenseInvalidSPDX},
+		{"MIT OR", "", codeLicenseInvalidSPDX},
+		{"MIT AND", "", codeLicenseInvalidSPDX},
+	}
+	for _, tc := range cases {
+		dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Licensed.\nlicense: "+tc.license+"\n---\nBody\n")
+		if tc.file != "" {
+			if err := os.WriteFile(filepath.Join(dir, tc.file), []byte("terms\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		result, err := ValidateSkill(dir, Options{CheckRefsExist: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tc.code == "" {
+			if len(result.Warnings) != 0 || len(result.Errors) != 0 {
+				t.Fatalf("%q: expected no findings, got %#v", tc.license, result)
+			}
+			continue
+		}
+		if result.Valid {
+			t.Fatalf("%q: expected the skill to be invalid", tc.license)
+		}
+		assertFinding(t, result, LevelError, tc.code)
+	}
+}
+
+func TestLicensePolicy(t *testing.T) {
+	cases := []struct {
+		license string
+		opts    Options
+		allowed bool
+	}{
+		{"MIT", Options{AllowedLicenses: []string{"mit", "Apache-2.0"}}, true},
+		{"GPL-3.0-only", Options{AllowedLicenses: []string{"MIT"}}, false},
+		{"MIT OR GPL-3.0-only", Options{DeniedLicenses: []string{"GPL-3.0-only"}}, true},
+		{"MIT AND GPL-3.0-only", Options{DeniedLicenses: []string{"GPL-3.0-only"}}, false},
+		{"LicenseRef-Internal", Options{AllowedLicenses: []string{"LicenseRef-Internal"}}, true},
+		{"GPL-2.0-or-later", Options{DeniedLicenses: []string{"GPL-2.0-only"}}, false},
+		{"GPL-2.0+", Options{DeniedLicenses: []string{"GPL-2.0-only"}}, false},
+		{"GPL-2.0-or-later WITH Classpath-exception-2.0", Options{DeniedLicenses: []string{"GPL-2.0-only"}}, false},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", Options{AllowedLicenses: []string{"GPL-2.0-or-later"}}, false},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", Options{AllowedLicenses: []string{"GPL-2.0-or-later", "Classpath-exception-2.0"}}, true},
+		{"Apache-2.0 WITH LLVM-exception", Options{DeniedLicenses: []string{"LLVM-exception"}}, false},
+	}
+	for _, tc := range cases {
+		dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Licensed.\nlicense: "+tc.license+"\n---\nBody\n")
+		result, err := ValidateSkill(dir, tc.opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Valid != tc.allowed {
+			t.Fatalf("%q: expected valid=%v, got %#v", tc.license, tc.allowed, result)
+		}
+		if !tc.allowed {
+			assertFinding(t, result, LevelError, codeLicenseNotAllowed)
+		}
+	}
+}
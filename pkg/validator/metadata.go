@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MetadataSchema declares the metadata keys an organization expects.
+type MetadataSchema struct {
+	Keys map[string]MetadataKey
+	// RejectUnknown reports metadata keys that are not in Keys.
+	RejectUnknown bool
+}
+
+// MetadataKey constrains the value of one metadata key. Pattern and Enum
+// are optional; a value must satisfy both when both are set. Pattern must
+// match the whole value, as if it were wrapped in ^(?:...)$.
+type MetadataKey struct {
+	Required bool
+	Pattern  *regexp.Regexp
+	Enum     []string
+}
+
+// metadataEntries returns the key and value nodes of the metadata mapping.
+func metadataEntries(ctx *SkillContext) [][2]*yaml.Node {
+	node := valueNode(ctx.Node, "metadata")
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	entries := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		entries = append(entries, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+	return entries
+}
+
+var metadataChecks = map[string]func(ctx *SkillContext) []Finding{
+	codeMetadataKeyMissing: func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		schema := ctx.Options.MetadataSchema
+		if schema == nil {
+			return findings
+		}
+		if _, ok := ctx.Frontmatter["metadata"]; ok && valueNode(ctx.Node, "metadata").Kind != yaml.MappingNode {
+			return findings
+		}
+		present := make(map[string]bool)
+		for _, entry := range metadataEntries(ctx) {
+			present[entry[0].Value] = true
+		}
+		for _, key := range sortedKeys(schema.Keys) {
+			if schema.Keys[key].Required && !present[key] {
+				findings = append(findings, ctx.KeyFinding("metadata", fmt.Sprintf("Metadata key '%s' is required.", key)))
+			}
+		}
+		return findings
+	},
+	codeMetadataValueInvalid: func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		schema := ctx.Options.MetadataSchema
+		if schema == nil {
+			return findings
+		}
+		for _, entry := range metadataEntries(ctx) {
+			key, value := entry[0], entry[1]
+			rule, ok := schema.Keys[key.Value]
+			if !ok || value.Kind != yaml.ScalarNode {
+				continue
+			}
+			if rule.Pattern != nil && !matchesWhole(rule.Pattern, value.Value) {
+				findings = append(findings, ctx.NodeFinding(value, fmt.Sprintf("Metadata '%s' value '%s' does not match %s.", key.Value, value.Value, rule.Pattern)))
+				continue
+			}
+			if len(rule.Enum) > 0 && !contains(rule.Enum, value.Value) {
+				findings = append(findings, ctx.NodeFinding(value, fmt.Sprintf("Metadata '%s' value '%s' must be one of: %s.", key.Value, value.Value, strings.Join(rule.Enum, ", "))))
+			}
+		}
+		return findings
+	},
+	codeMetadataKeyUnknown: func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		schema := ctx.Options.MetadataSchema
+		if schema == nil || !schema.RejectUnknown {
+			return findings
+		}
+		for _, entry := range metadataEntries(ctx) {
+			if _, ok := schema.Keys[entry[0].Value]; !ok {
+				findings = append(findings, ctx.NodeFinding(entry[0], fmt.Sprintf("Unknown metadata key '%s'.", entry[0].Value)))
+			}
+		}
+		return findings
+	},
+}
+
+// matchesWhole reports whether re matches all of s. With leftmost-longest
+// matching, a match starting at the beginning of s extends as far as re
+// allows, so it spans s exactly when some match does.
+func matchesWhole(re *regexp.Regexp, s string) bool {
+	longest := *re
+	longest.Longest()
+	loc := longest.FindStringIndex(s)
+	return loc != nil && loc[0] == 0 && loc[1] == len(s)
+}
+
+func sortedKeys(keys map[string]MetadataKey) []string {
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
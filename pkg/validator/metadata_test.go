@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+var teamSchema = &MetadataSchema{
+	Keys: map[string]MetadataKey{
+		"author":     {Required: true},
+		"version":    {Required: true, Pattern: regexp.MustCompile(`^\d+\.\d+\.\d+$`)},
+		"owner-team": {Required: true, Enum: []string{"docs", "platform"}},
+	},
+	RejectUnknown: true,
+}
+
+func TestMetadataSchema(t *testing.T) {
+	dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Owned.\nmetadata:\n  version: \"1.2\"\n  owner-team: growth\n  auther: Jane\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{MetadataSchema: teamSchema})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make([]Finding, 0)
+	for _, finding := range append(result.Errors, result.Warnings...) {
+		got = append(got, Finding{Code: finding.Code, Message: finding.Message, Line: finding.Line, Column: finding.Column})
+	}
+	want := []Finding{
+		{Code: codeMetadataKeyMissing, Message: "Metadata key 'author' is required.", Line: 4, Column: 1},
+		{Code: codeMetadataValueInvalid, Message: `Metadata 'version' value '1.2' does not match ^\d+\.\d+\.\d+$.`, Line: 5, Column: 12},
+		{Code: codeMetadataValueInvalid, Message: "Metadata 'owner-team' value 'growth' must be one of: docs, platform.", Line: 6, Column: 15},
+		{Code: codeMetadataKeyUnknown, Message: "Unknown metadata key 'auther'.", Line: 7, Column: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
+
+func TestMetadataSchemaSatisfied(t *testing.T) {
+	dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Owned.\nmetadata:\n  author: Jane\n  version: \"1.2.0\"\n  owner-team: docs\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{MetadataSchema: teamSchema})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Valid || len(result.Warnings) != 0 {
+		t.Fatalf("expected a valid skill, got %#v", result)
+	}
+
+	dir = writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Unowned.\n---\nBody\n")
+	result, err = ValidateSkill(dir, Options{MetadataSchema: teamSchema})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Errors) != 3 || result.Errors[0].Line != 1 {
+		t.Fatalf("expected three missing keys at the frontmatter, got %#v", result.Errors)
+	}
+}
+
+func TestMetadataPatternMatchesWholeValue(t *testing.T) {
+	schema := &MetadataSchema{Keys: map[string]MetadataKey{
+		"version": {Pattern: regexp.MustCompile(`\d+\.\d+\.\d+`)},
+		"channel": {Pattern: regexp.MustCompile(`beta|beta-\d+`)},
+	}}
+	cases := []struct {
+		version, channel string
+		valid            bool
+	}{
+		{"1.2.0", "beta", true},
+		{"1.2.0", "beta-2", true},
+		{"1.2.0-rc1", "beta", false},
+		{"v1.2.0", "beta", false},
+		{"1.2.0", "beta-", false},
+	}
+	for _, tc := range cases {
+		dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Versioned.\nmetadata:\n  version: "+tc.version+"\n  channel: "+tc.channel+"\n---\nBody\n")
+		result, err := ValidateSkill(dir, Options{MetadataSchema: schema})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Valid != tc.valid {
+			t.Fatalf("%s/%s: expected valid=%v, got %#v", tc.version, tc.channel, tc.valid, result.Errors)
+		}
+	}
+}
+
+func TestMetadataRejectUnknownIsError(t *testing.T) {
+	dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Owned.\nmetadata:\n  auther: Jane\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{MetadataSchema: &MetadataSchema{RejectUnknown: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Valid {
+		t.Fatalf("expected an unknown key to fail validation, got %#v", result)
+	}
+	assertFinding(t, result, LevelError, codeMetadataKeyUnknown)
+}
@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The portability rules flag YAML that yaml.v3 accepts but that simpler
+// parsers used by some agent hosts read differently. They only report when
+// Options.Portability is set.
+
+// stringFields are the top-level keys whose values must be strings.
+var stringFields = []string{"name", "description", "compatibility", "license", "allowed-tools"}
+
+var (
+	yaml11BoolPattern   = regexp.MustCompile(`^(?i:y|yes|n|no|on|off)$`)
+	documentMarkPattern = regexp.MustCompile(`^(---|\.\.\.)(\s|$)`)
+)
+
+// portabilityCheck reports every node of the frontmatter for which message
+// returns a non-empty string.
+func portabilityCheck(message func(node *yaml.Node) string) func(ctx *SkillContext) []Finding {
+	return func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		if !ctx.Options.Portability {
+			return findings
+		}
+		walkNodes(ctx.Node, func(node *yaml.Node) {
+			if msg := message(node); msg != "" {
+				findings = append(findings, ctx.NodeFinding(node, msg))
+			}
+		})
+		return findings
+	}
+}
+
+// walkNodes calls fn for node and everything nested in it. Aliases are not
+// followed.
+func walkNodes(node *yaml.Node, fn func(node *yaml.Node)) {
+	fn(node)
+	for _, child := range node.Content {
+		walkNodes(child, fn)
+	}
+}
+
+var portabilityChecks = map[string]func(ctx *SkillContext) []Finding{
+	codeYAMLAnchor: portabilityCheck(func(node *yaml.Node) string {
+		if node.Anchor == "" {
+			return ""
+		}
+		return fmt.Sprintf("Frontmatter defines the YAML anchor '&%s'; some hosts do not support anchors.", node.Anchor)
+	}),
+	codeYAMLAlias: portabilityCheck(func(node *yaml.Node) string {
+		if node.Kind != yaml.AliasNode {
+			return ""
+		}
+		return fmt.Sprintf("Frontmatter uses the YAML alias '*%s'; some hosts do not support aliases.", node.Value)
+	}),
+	codeYAMLExplicitTag: portabilityCheck(func(node *yaml.Node) string {
+		if node.Style&yaml.TaggedStyle == 0 {
+			return ""
+		}
+		return fmt.Sprintf("Frontmatter uses the explicit YAML tag '%s'; some hosts ignore or reject tags.", node.Tag)
+	}),
+	codeYAMLMergeKey: portabilityCheck(func(node *yaml.Node) string {
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!merge" {
+			return ""
+		}
+		return "Frontmatter uses the YAML merge key '<<'; some hosts read it as a plain key."
+	}),
+	codeYAML11Boolean: func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		if !ctx.Options.Portability {
+			return findings
+		}
+		for _, value := range stringValues(ctx.Node) {
+			if value.Kind == yaml.ScalarNode && value.Style == 0 && yaml11BoolPattern.MatchString(value.Value) {
+				findings = append(findings, ctx.NodeFinding(value,
+					fmt.Sprintf("Unquoted '%s' is a boolean in YAML 1.1; quote it so every host reads a string.", value.Value)))
+			}
+		}
+		return findings
+	},
+	codeYAMLMultipleDocuments: func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		if !ctx.Options.Portability {
+			return findings
+		}
+		for i, line := range strings.Split(ctx.source.YAML, "\n") {
+			if documentMarkPattern.MatchString(line) {
+				findings = append(findings, ctx.LineFinding(ctx.source.YAMLStartLine+i,
+					"Frontmatter contains a YAML document marker; hosts disagree on which document they read."))
+			}
+		}
+		return findings
+	},
+}
+
+// stringValues returns the value nodes of the top-level string fields and of
+// the metadata entries.
+func stringValues(root *yaml.Node) []*yaml.Node {
+	values := make([]*yaml.Node, 0)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+		if key == "metadata" && value.Kind == yaml.MappingNode {
+			for j := 1; j < len(value.Content); j += 2 {
+				values = append(values, value.Content[j])
+			}
+			continue
+		}
+		for _, field := range stringFields {
+			if key == field {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const portabilitySkill = `---
+name: my-skill
+description: &desc Portable.
+compatibility: *desc
+license: !!str MIT
+metadata:
+  <<: {team: docs}
+  experimental: yes
+  stable: "no"
+...
+---
+Body
+`
+
+func TestPortabilityRules(t *testing.T) {
+	dir := writeSkill(t, "my-skill", portabilitySkill)
+
+	result, err := ValidateSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Valid || len(result.Warnings) != 0 {
+		t.Fatalf("expected portability rules to be off by default, got %#v", result)
+	}
+
+	result, err = ValidateSkill(dir, Options{Portability: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make([]string, 0, len(result.Warnings))
+	for _, finding := range result.Warnings {
+		got = append(got, finding.Code+":"+strconv.Itoa(finding.Line)+":"+strconv.Itoa(finding.Column))
+	}
+	want := []string{
+		"YAML_ANCHOR:3:14",
+		"YAML_ALIAS:4:16",
+		"YAML_EXPLICIT_TAG:5:10",
+		"YAML_MERGE_KEY:7:3",
+		"YAML_11_BOOLEAN:8:17",
+		"YAML_MULTIPLE_DOCUMENTS:10:1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for _, finding := range result.Warnings {
+		if finding.Code == codeYAML11Boolean && !strings.Contains(finding.Message, "'yes'") {
+			t.Fatalf("unexpected message %q", finding.Message)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package validator
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	yamlErrorLinePattern = regexp.MustCompile(`line (\d+)`)
+	utf8BOM              = []byte{0xEF, 0xBB, 0xBF}
+)
+
+// span locates a finding within a file. Lines and columns are 1-based and
+// columns count characters; endColumn is exclusive. The zero span means the
+// finding has no position inside the file.
+type span struct {
+	line      int
+	column    int
+	endLine   int
+	endColumn int
+}
+
+// lineIndex maps line numbers of SKILL.md to byte offsets.
+type lineIndex struct {
+	content []byte
+	starts  []int
+}
+
+func newLineIndex(content []byte) lineIndex {
+	starts := []int{0}
+	for i, b := range content {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return lineIndex{content: content, starts: starts}
+}
+
+// line returns the text of the 1-based line without its line terminator,
+// together with the byte offset at which it starts.
+func (l lineIndex) line(n int) (string, int, bool) {
+	if n < 1 || n > len(l.starts) {
+		return "", 0, false
+	}
+	start := l.starts[n-1]
+	end := len(l.content)
+	if n < len(l.starts) {
+		end = l.starts[n] - 1
+	}
+	if n == 1 && bytes.HasPrefix(l.content[:end], utf8BOM) {
+		start += len(utf8BOM)
+	}
+	return strings.TrimSuffix(string(l.content[start:end]), "\r"), start, true
+}
+
+// lineSpan covers the whole of line n.
+func (l lineIndex) lineSpan(n int) span {
+	return l.rangeSpan(n, n)
+}
+
+// rangeSpan covers lines first through last.
+func (l lineIndex) rangeSpan(first, last int) span {
+	if last < first {
+		last = first
+	}
+	text, _, ok := l.line(last)
+	if !ok {
+		return span{line: first}
+	}
+	return span{line: first, column: 1, endLine: last, endColumn: utf8.RuneCountInString(text) + 1}
+}
+
+// textSpan covers text[start:end] of line n, given as byte offsets.
+func textSpan(n int, text string, start, end int) span {
+	return span{
+		line:      n,
+		column:    utf8.RuneCountInString(text[:start]) + 1,
+		endLine:   n,
+		endColumn: utf8.RuneCountInString(text[:end]) + 1,
+	}
+}
+
+// nodeSpan covers a YAML node of the frontmatter. Single-line scalars are
+// covered exactly; other nodes from their start to the end of that line.
+func (l lineIndex) nodeSpan(yamlStartLine int, node *yaml.Node) span {
+	n := yamlStartLine + node.Line - 1
+	if start, end, ok := l.scalarSpan(yamlStartLine, node); ok {
+		text, lineStart, _ := l.line(n)
+		return textSpan(n, text, start-lineStart, end-lineStart)
+	}
+	text, _, ok := l.line(n)
+	if !ok {
+		return span{line: n}
+	}
+	return span{line: n, column: node.Column, endLine: n, endColumn: utf8.RuneCountInString(text) + 1}
+}
+
+// yamlErrorSpan covers the frontmatter line named in a yaml.v3 error message,
+// or the opening delimiter when the message has no line.
+func (l lineIndex) yamlErrorSpan(yamlStartLine int, err error) span {
+	match := yamlErrorLinePattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return l.lineSpan(1)
+	}
+	line, convErr := strconv.Atoi(match[1])
+	if convErr != nil {
+		return l.lineSpan(1)
+	}
+	return l.lineSpan(yamlStartLine + line - 1)
+}
+
+// scalarSpan returns the byte range of a single-line plain or quoted scalar
+// in the file. yamlStartLine is the file line on which the frontmatter YAML
+// begins.
+func (l lineIndex) scalarSpan(yamlStartLine int, node *yaml.Node) (int, int, bool) {
+	text, lineStart, ok := l.line(yamlStartLine + node.Line - 1)
+	if !ok {
+		return 0, 0, false
+	}
+	offset := 0
+	for column := 1; column < node.Column; column++ {
+		if offset >= len(text) {
+			return 0, 0, false
+		}
+		_, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+	}
+	rest := text[offset:]
+
+	length := -1
+	switch node.Style {
+	case 0:
+		if node.Value != "" && strings.HasPrefix(rest, node.Value) {
+			length = len(node.Value)
+		}
+	case yaml.DoubleQuotedStyle:
+		for i := 1; i < len(rest); i++ {
+			if rest[i] == '\\' {
+				i++
+				continue
+			}
+			if rest[i] == '"' {
+				length = i + 1
+				break
+			}
+		}
+	case yaml.SingleQuotedStyle:
+		for i := 1; i < len(rest); i++ {
+			if rest[i] != '\'' {
+				continue
+			}
+			if i+1 < len(rest) && rest[i+1] == '\'' {
+				i++
+				continue
+			}
+			length = i + 1
+			break
+		}
+	}
+	if length < 0 {
+		return 0, 0, false
+	}
+	return lineStart + offset, lineStart + offset + length, true
+}
+
+// substringSpan covers the bytes start to end of the value of a single-line
+// scalar. It fails when the value is not written verbatim in the file, as
+// with escape sequences and folded or multi-line scalars.
+func (l lineIndex) substringSpan(yamlStartLine int, node *yaml.Node, start, end int) (span, bool) {
+	first, last, ok := l.scalarSpan(yamlStartLine, node)
+	if !ok {
+		return span{}, false
+	}
+	if node.Style != 0 {
+		first, last = first+1, last-1
+	}
+	if string(l.content[first:last]) != node.Value {
+		return span{}, false
+	}
+	n := yamlStartLine + node.Line - 1
+	text, lineStart, _ := l.line(n)
+	return textSpan(n, text, first-lineStart+start, first-lineStart+end), true
+}
+
+// position returns the 1-based line and character column of a byte offset.
+func (l lineIndex) position(offset int) (int, int) {
+	n := sort.Search(len(l.starts), func(i int) bool { return l.starts[i] > offset })
+	_, start, _ := l.line(n)
+	if offset < start {
+		offset = start
+	}
+	return n, utf8.RuneCount(l.content[start:offset]) + 1
+}
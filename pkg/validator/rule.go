@@ -0,0 +1,141 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sven1103-agent/sklint/internal/parse"
+)
+
+// Rule is a check run against every skill whose SKILL.md has a frontmatter
+// mapping. Each rule reports a single finding code, its ID, so that
+// severity overrides, inline suppressions and NoWarn apply to custom rules
+// exactly as they do to the built-in ones.
+type Rule interface {
+	// ID is the finding code, e.g. "ACME_OWNER_MISSING".
+	ID() string
+	// DefaultSeverity is LevelError or LevelWarning.
+	DefaultSeverity() FindingLevel
+	// Doc is a one-line description of what the rule reports.
+	Doc() string
+	// Check returns the rule's findings for the skill. Code and Level are
+	// set from ID and DefaultSeverity; the SkillContext helpers fill in the
+	// location.
+	Check(ctx *SkillContext) []Finding
+}
+
+// SkillContext is the skill a Rule checks.
+type SkillContext struct {
+	// FS holds the skill's files; Dir is the skill directory within it.
+	FS  fs.FS
+	Dir string
+	// DirName is the name the skill directory has on disk, or "" when it is
+	// not known.
+	DirName string
+	Options Options
+	// Content is the source of SKILL.md.
+	Content []byte
+	// Frontmatter is the decoded frontmatter and Node its YAML mapping node.
+	Frontmatter map[string]any
+	Node        *yaml.Node
+	// Body is the Markdown after the frontmatter; it starts on line
+	// BodyStartLine of SKILL.md.
+	Body          string
+	BodyStartLine int
+
+	source parse.Frontmatter
+	lines  lineIndex
+	keys   keySpans
+	refs   []reference
+	status map[string]refStatus
+}
+
+// KeyFinding returns a finding in SKILL.md located at the top-level key, or
+// at the opening frontmatter delimiter when the key is missing.
+func (ctx *SkillContext) KeyFinding(key, message string) Finding {
+	return ctx.at(ctx.keys.key(key), message)
+}
+
+// ValueFinding returns a finding in SKILL.md located at the value of the
+// top-level key. Values that start on a later line, such as nested
+// mappings, are located at their key.
+func (ctx *SkillContext) ValueFinding(key, message string) Finding {
+	return ctx.at(ctx.keys.value(key), message)
+}
+
+// NodeFinding returns a finding in SKILL.md located at a node of the
+// frontmatter, such as a nested key of Node.
+func (ctx *SkillContext) NodeFinding(node *yaml.Node, message string) Finding {
+	return ctx.at(ctx.lines.nodeSpan(ctx.source.YAMLStartLine, node), message)
+}
+
+// LineFinding returns a finding in SKILL.md covering the 1-based line.
+func (ctx *SkillContext) LineFinding(line int, message string) Finding {
+	return ctx.at(ctx.lines.lineSpan(line), message)
+}
+
+func (ctx *SkillContext) at(s span, message string) Finding {
+	return newFinding("", "", message, "SKILL.md", s)
+}
+
+var registry = struct {
+	sync.RWMutex
+	rules []Rule
+	ids   map[string]bool
+}{rules: builtinRules(), ids: make(map[string]bool)}
+
+// Register adds a rule that runs on every skill validated afterwards, next
+// to the built-in rules. It fails when the ID is empty or already used by
+// another rule or built-in code, or when the default severity is neither
+// LevelError nor LevelWarning.
+func Register(rule Rule) error {
+	if rule == nil || rule.ID() == "" {
+		return errors.New("rule has no ID")
+	}
+	if level := rule.DefaultSeverity(); level != LevelError && level != LevelWarning {
+		return fmt.Errorf("rule %s: invalid default severity %q", rule.ID(), level)
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if registry.ids[rule.ID()] || isBuiltinCode(rule.ID()) {
+		return fmt.Errorf("rule %s is already registered", rule.ID())
+	}
+	registry.ids[rule.ID()] = true
+	registry.rules = append(registry.rules, rule)
+	return nil
+}
+
+// Rules returns the built-in rules followed by the registered ones.
+func Rules() []Rule {
+	registry.RLock()
+	defer registry.RUnlock()
+	return append([]Rule(nil), registry.rules...)
+}
+
+func isBuiltinCode(code string) bool {
+	for _, info := range codeInfos {
+		if info.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// runRules adds the findings of every rule to result.
+func runRules(result *Result, ctx *SkillContext) {
+	for _, rule := range Rules() {
+		for _, finding := range rule.Check(ctx) {
+			finding.Code = rule.ID()
+			finding.Level = rule.DefaultSeverity()
+			if finding.Level == LevelError {
+				result.Errors = append(result.Errors, finding)
+			} else {
+				result.Warnings = append(result.Warnings, finding)
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// teamRule is a custom rule of the kind an organization might register.
+type teamRule struct{}
+
+func (teamRule) ID() string                    { return "ACME_TEAM_UNKNOWN" }
+func (teamRule) DefaultSeverity() FindingLevel { return LevelWarning }
+func (teamRule) Doc() string                   { return "x-team names a team that does not exist" }
+
+func (teamRule) Check(ctx *SkillContext) []Finding {
+	team, ok := ctx.Frontmatter["x-team"].(string)
+	if !ok || team == "docs" {
+		return nil
+	}
+	return []Finding{ctx.ValueFinding("x-team", fmt.Sprintf("Unknown team '%s'.", team))}
+}
+
+var registerTeamRule sync.Once
+
+func withTeamRule(t *testing.T) {
+	t.Helper()
+	registerTeamRule.Do(func() {
+		if err := Register(teamRule{}); err != nil {
+			t.Fatalf("register: %v", err)
+		}
+	})
+}
+
+func TestCustomRule(t *testing.T) {
+	withTeamRule(t)
+	dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Owned.\nx-team: growth\n---\nBody\n")
+
+	result, err := ValidateSkill(dir, Options{Severity: map[string]FindingLevel{codeUnknownTopLevelKey: LevelOff}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Warnings) != 1 {
+		t.Fatalf("expected one warning, got %#v", result.Warnings)
+	}
+	got := result.Warnings[0]
+	want := Finding{Level: LevelWarning, Code: "ACME_TEAM_UNKNOWN", Message: "Unknown team 'growth'.", File: "SKILL.md", Line: 4, Column: 9, EndLine: 4, EndColumn: 15}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+
+	result, err = ValidateSkill(dir, Options{Severity: map[string]FindingLevel{"ACME_TEAM_UNKNOWN": LevelError}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, "ACME_TEAM_UNKNOWN")
+	if result.Valid {
+		t.Fatal("expected the promoted finding to invalidate the skill")
+	}
+}
+
+func TestCustomRuleSuppression(t *testing.T) {
+	withTeamRule(t)
+	dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Owned.\n# sklint-disable ACME_TEAM_UNKNOWN UNKNOWN_TOP_LEVEL_KEY\nx-team: growth\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Warnings) != 0 || len(result.Suppressed) != 2 {
+		t.Fatalf("expected both findings to be suppressed, got %#v", result)
+	}
+}
+
+func TestCustomRuleIsListed(t *testing.T) {
+	withTeamRule(t)
+	found := false
+	for _, info := range Codes() {
+		if info.Code == "ACME_TEAM_UNKNOWN" {
+			found = info.Level == LevelWarning && info.Description == teamRule{}.Doc()
+		}
+	}
+	if !found {
+		t.Fatal("expected registered rule in Codes()")
+	}
+}
+
+type badRule struct {
+	teamRule
+	id    string
+	level FindingLevel
+}
+
+func (r badRule) ID() string                    { return r.id }
+func (r badRule) DefaultSeverity() FindingLevel { return r.level }
+
+func TestRegisterRejectsInvalidRules(t *testing.T) {
+	withTeamRule(t)
+	cases := []badRule{
+		{id: "", level: LevelError},
+		{id: "ACME_TEAM_UNKNOWN", level: LevelWarning},
+		{id: codeNameMissing, level: LevelError},
+		{id: codeSkillMDMissing, level: LevelError},
+		{id: "ACME_OFF", level: LevelOff},
+	}
+	for _, rule := range cases {
+		if err := Register(rule); err == nil {
+			t.Fatalf("expected %q to be rejected", rule.id)
+		}
+	}
+}
+
+func TestBuiltinRulesHaveMetadata(t *testing.T) {
+	for _, rule := range Rules() {
+		if _, ok := rule.(builtinRule); !ok {
+			continue
+		}
+		if rule.Doc() == "" || (rule.DefaultSeverity() != LevelError && rule.DefaultSeverity() != LevelWarning) {
+			t.Fatalf("rule %s lacks metadata", rule.ID())
+		}
+	}
+	if len(builtinRules()) != len(builtinChecks)+len(metadataChecks)+len(toolChecks)+len(licenseChecks)+len(portabilityChecks) {
+		t.Fatal("every built-in check needs an entry in codeInfos")
+	}
+}
+
+func TestEveryCodeIsExplained(t *testing.T) {
+	for _, info := range codeInfos {
+		doc, ok := codeDocs[info.Code]
+		if !ok || doc.Details == "" || doc.Fix == "" {
+			t.Errorf("code %s has no explanation", info.Code)
+		}
+		if info.Category == "" {
+			t.Errorf("code %s has no category", info.Code)
+		}
+		if fixable := nameFixCodes[info.Code] || info.Code == codeMetadataValueNotString || info.Code == codeAllowedToolsWhitespace; info.Fixable != fixable {
+			t.Errorf("code %s: expected fixable=%v", info.Code, fixable)
+		}
+	}
+	if len(codeDocs) != len(codeInfos) {
+		t.Errorf("expected %d explanations, got %d", len(codeInfos), len(codeDocs))
+	}
+}
+
+func TestExplain(t *testing.T) {
+	withTeamRule(t)
+	info, doc, ok := Explain("name_invalid_chars")
+	if !ok || info.Code != codeNameInvalidChars || !info.Fixable || doc.Bad == "" || doc.Good == "" {
+		t.Fatalf("unexpected explanation %#v %#v", info, doc)
+	}
+	info, doc, ok = Explain("ACME_TEAM_UNKNOWN")
+	if !ok || info.Category != CategoryCustom || doc.Details != (teamRule{}).Doc()+"." {
+		t.Fatalf("unexpected explanation %#v %#v", info, doc)
+	}
+	if _, _, ok := Explain("NO_SUCH_CODE"); ok {
+		t.Fatal("expected unknown code")
+	}
+}
@@ -0,0 +1,330 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// builtinRule is a rule whose metadata comes from the codeInfos table.
+type builtinRule struct {
+	info  CodeInfo
+	check func(ctx *SkillContext) []Finding
+}
+
+func (r builtinRule) ID() string                        { return r.info.Code }
+func (r builtinRule) DefaultSeverity() FindingLevel     { return r.info.Level }
+func (r builtinRule) Doc() string                       { return r.info.Description }
+func (r builtinRule) Check(ctx *SkillContext) []Finding { return r.check(ctx) }
+
+// builtinChecks implements the codes of codeInfos that are checked once the
+// frontmatter has been parsed. The remaining codes describe the structure
+// of the skill directory and SKILL.md and are reported before rules run.
+var builtinChecks = map[string]func(ctx *SkillContext) []Finding{
+	codeNameMissing: func(ctx *SkillContext) []Finding {
+		if _, ok := ctx.Frontmatter["name"]; ok {
+			return nil
+		}
+		return []Finding{ctx.at(ctx.keys.frontmatter, "Frontmatter 'name' is required.")}
+	},
+	codeNameNotString: notString("name"),
+	codeNameTooShort: stringCheck("name", func(name string) bool { return len(name) < 1 },
+		"Frontmatter 'name' must be at least 1 character."),
+	codeNameTooLong: stringCheck("name", func(name string) bool { return len(name) > 64 },
+		"Frontmatter 'name' must be at most 64 characters."),
+	codeNameInvalidChars: stringCheck("name", func(name string) bool { return !namePattern.MatchString(name) },
+		"Frontmatter 'name' must use lowercase letters, digits, and hyphens only."),
+	codeNameStartsWithHyphen: stringCheck("name", func(name string) bool { return strings.HasPrefix(name, "-") },
+		"Frontmatter 'name' must not start with '-'."),
+	codeNameEndsWithHyphen: stringCheck("name", func(name string) bool { return strings.HasSuffix(name, "-") },
+		"Frontmatter 'name' must not end with '-'."),
+	codeNameConsecutiveHyphens: stringCheck("name", func(name string) bool { return strings.Contains(name, "--") },
+		"Frontmatter 'name' must not contain consecutive hyphens."),
+	codeNameMismatchDirectory: func(ctx *SkillContext) []Finding {
+		name, ok := ctx.Frontmatter["name"].(string)
+		if !ok || ctx.DirName == "" || name == ctx.DirName {
+			return nil
+		}
+		return []Finding{ctx.ValueFinding("name", fmt.Sprintf("Frontmatter name '%s' must match directory name '%s'.", name, ctx.DirName))}
+	},
+
+	codeDescriptionMissing: func(ctx *SkillContext) []Finding {
+		if _, ok := ctx.Frontmatter["description"]; ok {
+			return nil
+		}
+		return []Finding{ctx.at(ctx.keys.frontmatter, "Frontmatter 'description' is required.")}
+	},
+	codeDescriptionNotString: notString("description"),
+	codeDescriptionTooShort: stringCheck("description", func(desc string) bool { return len(desc) < 1 },
+		"Frontmatter 'description' must be at least 1 character."),
+	codeDescriptionTooLong: stringCheck("description", func(desc string) bool { return len(desc) > 1024 },
+		"Frontmatter 'description' must be at most 1024 characters."),
+
+	codeCompatibilityNotString: notString("compatibility"),
+	codeCompatibilityTooShort: stringCheck("compatibility", func(comp string) bool { return len(comp) < 1 },
+		"Frontmatter 'compatibility' must be at least 1 character."),
+	codeCompatibilityTooLong: stringCheck("compatibility", func(comp string) bool { return len(comp) > 500 },
+		"Frontmatter 'compatibility' must be at most 500 characters."),
+
+	codeLicenseNotString: notString("license"),
+
+	codeMetadataNotObject: func(ctx *SkillContext) []Finding {
+		value, ok := ctx.Frontmatter["metadata"]
+		if !ok {
+			return nil
+		}
+		switch value.(type) {
+		case map[string]any:
+			return nil
+		case map[any]any:
+			return []Finding{ctx.ValueFinding("metadata", "Frontmatter 'metadata' must be an object with string keys.")}
+		}
+		return []Finding{ctx.ValueFinding("metadata", "Frontmatter 'metadata' must be an object.")}
+	},
+	codeMetadataValueNotString: func(ctx *SkillContext) []Finding {
+		metadata, ok := ctx.Frontmatter["metadata"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		for _, v := range metadata {
+			if _, ok := v.(string); !ok {
+				return []Finding{ctx.ValueFinding("metadata", "Frontmatter 'metadata' values must be strings.")}
+			}
+		}
+		return nil
+	},
+
+	codeAllowedToolsNotString: notString("allowed-tools"),
+	codeAllowedToolsEmpty: stringCheck("allowed-tools", func(tools string) bool { return strings.TrimSpace(tools) == "" },
+		"Frontmatter 'allowed-tools' must not be empty."),
+
+	codeUnknownTopLevelKey: func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		for _, key := range collectUnknownKeys(ctx.Node) {
+			findings = append(findings, ctx.KeyFinding(key, fmt.Sprintf("Unknown top-level key '%s'.", key)))
+		}
+		return findings
+	},
+	codeSkillMDTooLongLines: func(ctx *SkillContext) []Finding {
+		if ctx.source.LineCount <= 500 {
+			return nil
+		}
+		return []Finding{ctx.at(ctx.lines.rangeSpan(501, ctx.source.LineCount), fmt.Sprintf("SKILL.md is %d lines; recommended under 500 lines.", ctx.source.LineCount))}
+	},
+	codeSkillMDMissingBody: func(ctx *SkillContext) []Finding {
+		if strings.TrimSpace(ctx.Body) != "" {
+			return nil
+		}
+		return []Finding{ctx.LineFinding(ctx.BodyStartLine-1, "SKILL.md body is empty.")}
+	},
+
+	codeRefContainsDotDot: func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		for _, ref := range ctx.references() {
+			if hasDotDot(ref.path) {
+				findings = append(findings, ctx.at(ref.at, fmt.Sprintf("Reference '%s' contains '..' path segments.", ref.path)))
+			}
+		}
+		return findings
+	},
+	codeRefTooDeep: func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		for _, ref := range ctx.references() {
+			if strings.Count(strings.TrimPrefix(ref.path, "./"), "/") > 1 {
+				findings = append(findings, ctx.at(ref.at, fmt.Sprintf("Reference '%s' is nested deeper than one level.", ref.path)))
+			}
+		}
+		return findings
+	},
+	codeRefMissingFile: refStatusCheck(map[refStatus]string{
+		refMissing:      "Reference '%s' does not exist.",
+		refUnresolvable: "Reference '%s' could not be resolved.",
+	}),
+	codeRefEscapesRoot: refStatusCheck(map[refStatus]string{
+		refEscapes: "Reference '%s' resolves outside the skill directory.",
+	}),
+}
+
+func builtinRules() []Rule {
+	rules := make([]Rule, 0, len(codeInfos))
+	for _, info := range codeInfos {
+		for _, checks := range []map[string]func(ctx *SkillContext) []Finding{builtinChecks, metadataChecks, toolChecks, licenseChecks, portabilityChecks} {
+			if check, ok := checks[info.Code]; ok {
+				rules = append(rules, builtinRule{info: info, check: check})
+			}
+		}
+	}
+	return rules
+}
+
+// notString reports a present top-level key whose value is not a string.
+func notString(key string) func(ctx *SkillContext) []Finding {
+	return func(ctx *SkillContext) []Finding {
+		value, ok := ctx.Frontmatter[key]
+		if !ok {
+			return nil
+		}
+		if _, ok := value.(string); ok {
+			return nil
+		}
+		return []Finding{ctx.ValueFinding(key, fmt.Sprintf("Frontmatter '%s' must be a string.", key))}
+	}
+}
+
+// stringCheck reports a string value of a top-level key for which bad
+// returns true.
+func stringCheck(key string, bad func(string) bool, message string) func(ctx *SkillContext) []Finding {
+	return func(ctx *SkillContext) []Finding {
+		value, ok := ctx.Frontmatter[key].(string)
+		if !ok || !bad(value) {
+			return nil
+		}
+		return []Finding{ctx.ValueFinding(key, message)}
+	}
+}
+
+// reference is a relative path mentioned in the body of SKILL.md, located
+// at its first occurrence.
+type reference struct {
+	path string
+	at   span
+}
+
+type refStatus int
+
+const (
+	refOK refStatus = iota
+	refEscapes
+	refMissing
+	refUnresolvable
+)
+
+// references returns the file references of the body, sorted by path.
+func (ctx *SkillContext) references() []reference {
+	if ctx.refs == nil {
+		ctx.refs = scanReferences(ctx.Body, ctx.BodyStartLine)
+	}
+	return ctx.refs
+}
+
+func refStatusCheck(messages map[refStatus]string) func(ctx *SkillContext) []Finding {
+	return func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		if !ctx.Options.CheckRefsExist {
+			return findings
+		}
+		for _, ref := range ctx.references() {
+			if message, ok := messages[ctx.refStatus(ref.path)]; ok {
+				findings = append(findings, ctx.at(ref.at, fmt.Sprintf(message, ref.path)))
+			}
+		}
+		return findings
+	}
+}
+
+// refStatus resolves a reference within the skill directory. Symlinks are
+// followed to check that they stay inside it unless FollowSymlinks is set.
+func (ctx *SkillContext) refStatus(ref string) refStatus {
+	if status, ok := ctx.status[ref]; ok {
+		return status
+	}
+	status := resolveRef(ctx.FS, ctx.Dir, ref, ctx.Options)
+	if ctx.status == nil {
+		ctx.status = make(map[string]refStatus)
+	}
+	ctx.status[ref] = status
+	return status
+}
+
+func resolveRef(fsys fs.FS, root, ref string, opts Options) refStatus {
+	target := path.Join(root, ref)
+	if !isWithinRoot(root, target) {
+		return refEscapes
+	}
+	info, err := lstat(fsys, target)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return refMissing
+		}
+		return refOK
+	}
+	if info.Mode()&fs.ModeSymlink == 0 || opts.FollowSymlinks {
+		return refOK
+	}
+	links, ok := fsys.(ReadLinkFS)
+	if !ok {
+		return refUnresolvable
+	}
+	_, escapes, err := resolveLink(links, root, target)
+	if err == nil && escapes {
+		_, err = fs.Stat(fsys, target)
+	}
+	if err != nil {
+		return refUnresolvable
+	}
+	if escapes {
+		return refEscapes
+	}
+	return refOK
+}
+
+func scanReferences(body string, bodyStartLine int) []reference {
+	seen := make(map[string]span)
+	lines := strings.Split(body, "\n")
+
+	lineStarts := make([]int, len(lines))
+	offset := 0
+	for i, line := range lines {
+		lineStarts[i] = offset
+		offset += len(line) + 1
+	}
+	for _, match := range linkPattern.FindAllStringSubmatchIndex(body, -1) {
+		if len(match) < 4 {
+			continue
+		}
+		start, end := match[2], match[3]
+		i := sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > start }) - 1
+		ref := strings.TrimSpace(body[start:end])
+		collectRef(ref, textSpan(bodyStartLine+i, lines[i], start-lineStarts[i], end-lineStarts[i]), seen)
+	}
+
+	for i, line := range lines {
+		for _, match := range plainRefPattern.FindAllStringIndex(line, -1) {
+			start, end := match[0], match[1]
+			for start < end && (line[start] == ' ' || line[start] == '\t') {
+				start++
+			}
+			for end > start && strings.ContainsRune(".,;:)", rune(line[end-1])) {
+				end--
+			}
+			collectRef(line[start:end], textSpan(bodyStartLine+i, line, start, end), seen)
+		}
+	}
+
+	refs := make([]reference, 0, len(seen))
+	for ref, at := range seen {
+		refs = append(refs, reference{path: ref, at: at})
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].path < refs[j].path })
+	return refs
+}
+
+// collectRef records ref together with where it first appears.
+func collectRef(ref string, at span, seen map[string]span) {
+	if ref == "" {
+		return
+	}
+	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+		return
+	}
+	if strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, "#") {
+		return
+	}
+	if first, ok := seen[ref]; ok && (first.line < at.line || (first.line == at.line && first.column <= at.column)) {
+		return
+	}
+	seen[ref] = at
+}
@@ -0,0 +1,199 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sven1103-agent/sklint/internal/parse"
+)
+
+var (
+	disableNextLinePattern = regexp.MustCompile(`<!--\s*sklint-disable-next-line(?:\s+([^>]*?))?\s*-->`)
+	disableCommentPattern  = regexp.MustCompile(`^#\s*sklint-disable(?:\s+(.*?))?\s*$`)
+	codeListSeparator      = regexp.MustCompile(`[\s,]+`)
+)
+
+// suppression silences findings with the given codes (all codes when empty)
+// reported in SKILL.md between firstLine and lastLine inclusive.
+// Frontmatter suppressions cover the whole frontmatter, so they must list
+// their codes; a bare one would hide every finding, errors included.
+type suppression struct {
+	at          span
+	firstLine   int
+	lastLine    int
+	codes       []string
+	frontmatter bool
+	used        map[string]bool
+}
+
+// parseSuppressions collects the inline suppression comments of SKILL.md:
+// "<!-- sklint-disable-next-line CODE -->" in the body covers the line after
+// the comment, and "# sklint-disable CODE" anywhere in the frontmatter covers
+// the whole frontmatter. Frontmatter comments are taken from node, the parsed
+// frontmatter, so a "#" inside a quoted value is not mistaken for one.
+func parseSuppressions(frontmatter parse.Frontmatter, node *yaml.Node) []suppression {
+	suppressions := make([]suppression, 0)
+
+	comments := make(map[string]int)
+	collectComments(node, comments)
+	for i, line := range strings.Split(frontmatter.YAML, "\n") {
+		start, comment := findComment(line, comments)
+		if start < 0 {
+			continue
+		}
+		match := disableCommentPattern.FindStringSubmatchIndex(comment)
+		if match == nil {
+			continue
+		}
+		suppressions = append(suppressions, suppression{
+			at:          textSpan(frontmatter.YAMLStartLine+i, line, start, start+len(comment)),
+			firstLine:   0,
+			lastLine:    frontmatter.BodyStartLine - 1,
+			codes:       splitCodes(submatch(comment, match, 1)),
+			frontmatter: true,
+			used:        make(map[string]bool),
+		})
+	}
+
+	for i, line := range strings.Split(frontmatter.Body, "\n") {
+		for _, match := range disableNextLinePattern.FindAllStringSubmatchIndex(line, -1) {
+			lineNumber := frontmatter.BodyStartLine + i
+			suppressions = append(suppressions, suppression{
+				at:        textSpan(lineNumber, line, match[0], match[1]),
+				firstLine: lineNumber + 1,
+				lastLine:  lineNumber + 1,
+				codes:     splitCodes(submatch(line, match, 1)),
+				used:      make(map[string]bool),
+			})
+		}
+	}
+	return suppressions
+}
+
+// collectComments counts the comment lines attached to node and its
+// descendants.
+func collectComments(node *yaml.Node, comments map[string]int) {
+	if node == nil {
+		return
+	}
+	for _, comment := range []string{node.HeadComment, node.LineComment, node.FootComment} {
+		for _, line := range strings.Split(comment, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				comments[line]++
+			}
+		}
+	}
+	for _, child := range node.Content {
+		collectComments(child, comments)
+	}
+}
+
+// findComment returns the byte offset and text of the comment that ends
+// line, or -1 when the line ends in none of comments. The comment is
+// consumed, so each parsed comment is found on one line only.
+func findComment(line string, comments map[string]int) (int, string) {
+	line = strings.TrimRight(line, " \t\r")
+	for i := 0; i < len(line); i++ {
+		if line[i] != '#' || (i > 0 && line[i-1] != ' ' && line[i-1] != '\t') {
+			continue
+		}
+		if comments[line[i:]] > 0 {
+			comments[line[i:]]--
+			return i, line[i:]
+		}
+	}
+	return -1, ""
+}
+
+func submatch(s string, match []int, group int) string {
+	if match[2*group] < 0 {
+		return ""
+	}
+	return s[match[2*group]:match[2*group+1]]
+}
+
+func splitCodes(list string) []string {
+	codes := make([]string, 0)
+	for _, code := range codeListSeparator.Split(strings.TrimSpace(list), -1) {
+		if code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+func (s *suppression) matches(finding Finding) bool {
+	if finding.File != "SKILL.md" || finding.Line < s.firstLine || finding.Line > s.lastLine {
+		return false
+	}
+	if len(s.codes) == 0 {
+		if s.frontmatter {
+			return false
+		}
+		s.used[""] = true
+		return true
+	}
+	for _, code := range s.codes {
+		if code == finding.Code {
+			s.used[code] = true
+			return true
+		}
+	}
+	return false
+}
+
+// applySuppressions moves suppressed findings into result.Suppressed and
+// reports suppression comments that did not match anything. Severity
+// overrides apply to those reports as they do to every other finding.
+func applySuppressions(result *Result, suppressions []suppression, opts Options) {
+	if len(suppressions) == 0 {
+		return
+	}
+	result.Errors = filterSuppressed(result, result.Errors, suppressions)
+	result.Warnings = filterSuppressed(result, result.Warnings, suppressions)
+
+	var unused Result
+	for _, s := range suppressions {
+		if len(s.codes) == 0 && s.frontmatter {
+			addWarning(&unused, codeSuppressionUnused, "Suppression comment in the frontmatter must list the codes it suppresses.", "SKILL.md", s.at)
+			continue
+		}
+		if len(s.codes) == 0 {
+			if !s.used[""] {
+				addWarning(&unused, codeSuppressionUnused, "Suppression comment does not match any finding.", "SKILL.md", s.at)
+			}
+			continue
+		}
+		for _, code := range s.codes {
+			if s.used[code] || opts.Severity[code] == LevelOff {
+				continue
+			}
+			addWarning(&unused, codeSuppressionUnused, fmt.Sprintf("Suppression for %s does not match any finding.", code), "SKILL.md", s.at)
+		}
+	}
+	applySeverity(&unused, opts)
+	result.Errors = append(result.Errors, unused.Errors...)
+	result.Warnings = append(result.Warnings, unused.Warnings...)
+}
+
+func filterSuppressed(result *Result, findings []Finding, suppressions []suppression) []Finding {
+	kept := findings[:0]
+	for _, finding := range findings {
+		suppressed := false
+		for i := range suppressions {
+			if suppressions[i].matches(finding) {
+				suppressed = true
+			}
+		}
+		if suppressed {
+			finding.Suppressed = true
+			result.Suppressed = append(result.Suppressed, finding)
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept
+}
@@ -0,0 +1,190 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tool is an entry of the allowed-tools field, such as Read or
+// Bash(git status:*).
+type Tool struct {
+	Name string `json:"name"`
+	// Pattern is the text between the parentheses, e.g. "git status:*", or
+	// "" for tools that are allowed without restriction.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+func (t Tool) String() string {
+	if t.Pattern == "" {
+		return t.Name
+	}
+	return t.Name + "(" + t.Pattern + ")"
+}
+
+// mcpToolPrefix starts the names of tools provided by MCP servers. They are
+// configured per host, so they are never reported as unknown.
+const mcpToolPrefix = "mcp__"
+
+var toolPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*)(?:\((.*)\))?$`)
+
+// toolToken is an entry of allowed-tools, located by byte offsets into the
+// value. Entries are separated by whitespace or commas; separators inside
+// parentheses belong to the entry. problem is set when the entry is not a
+// valid tool.
+type toolToken struct {
+	text       string
+	start, end int
+	tool       Tool
+	problem    string
+}
+
+func parseAllowedTools(value string) []toolToken {
+	tokens := make([]toolToken, 0)
+	for i := 0; i < len(value); {
+		if isToolSeparator(value[i]) {
+			i++
+			continue
+		}
+		start, depth := i, 0
+		for ; i < len(value); i++ {
+			c := value[i]
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				depth--
+			} else if isToolSeparator(c) && depth <= 0 {
+				break
+			}
+		}
+		tokens = append(tokens, parseTool(value[start:i], start, i))
+	}
+	return tokens
+}
+
+func parseTool(text string, start, end int) toolToken {
+	token := toolToken{text: text, start: start, end: end}
+	match := toolPattern.FindStringSubmatch(text)
+	switch {
+	case !balanced(text):
+		token.problem = fmt.Sprintf("Tool '%s' in 'allowed-tools' has unbalanced parentheses.", text)
+	case match == nil || !balanced(match[2]):
+		token.problem = fmt.Sprintf("'%s' in 'allowed-tools' is not a tool name with an optional (pattern); separate tools with spaces.", text)
+	case strings.HasSuffix(text, "()"):
+		token.problem = fmt.Sprintf("Tool '%s' in 'allowed-tools' has an empty pattern.", text)
+	default:
+		token.tool = Tool{Name: match[1], Pattern: match[2]}
+	}
+	return token
+}
+
+// balanced reports whether every parenthesis in s is closed, in order.
+func balanced(s string) bool {
+	depth := 0
+	for _, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+func isToolSeparator(c byte) bool {
+	return c == ' ' || c == ',' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// allowedTools returns the valid tools of the allowed-tools field.
+func allowedTools(frontmatter map[string]any) []Tool {
+	value, ok := frontmatter["allowed-tools"].(string)
+	if !ok {
+		return nil
+	}
+	tools := make([]Tool, 0)
+	for _, token := range parseAllowedTools(value) {
+		if token.problem == "" {
+			tools = append(tools, token.tool)
+		}
+	}
+	if len(tools) == 0 {
+		return nil
+	}
+	return tools
+}
+
+// toolCheck reports the entries of allowed-tools for which message returns
+// a non-empty string, each at its position in the value where possible.
+func toolCheck(message func(ctx *SkillContext, token toolToken, seen map[Tool]bool) string) func(ctx *SkillContext) []Finding {
+	return func(ctx *SkillContext) []Finding {
+		findings := make([]Finding, 0)
+		value, ok := ctx.Frontmatter["allowed-tools"].(string)
+		if !ok {
+			return findings
+		}
+		node := valueNode(ctx.Node, "allowed-tools")
+		seen := make(map[Tool]bool)
+		for _, token := range parseAllowedTools(value) {
+			msg := message(ctx, token, seen)
+			if token.problem == "" {
+				seen[token.tool] = true
+			}
+			if msg == "" {
+				continue
+			}
+			if at, ok := ctx.lines.substringSpan(ctx.source.YAMLStartLine, node, token.start, token.end); ok {
+				findings = append(findings, ctx.at(at, msg))
+			} else {
+				findings = append(findings, ctx.ValueFinding("allowed-tools", msg))
+			}
+		}
+		return findings
+	}
+}
+
+// valueNode returns the value of key in the mapping node, or nil.
+func valueNode(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+var toolChecks = map[string]func(ctx *SkillContext) []Finding{
+	codeAllowedToolsWhitespace: func(ctx *SkillContext) []Finding {
+		value, ok := ctx.Frontmatter["allowed-tools"].(string)
+		if !ok || strings.TrimSpace(value) == "" || normalizeTools(value) == value {
+			return nil
+		}
+		return []Finding{ctx.ValueFinding("allowed-tools", "Frontmatter 'allowed-tools' entries should be separated by single spaces.")}
+	},
+	codeAllowedToolsInvalid: toolCheck(func(_ *SkillContext, token toolToken, _ map[Tool]bool) string {
+		return token.problem
+	}),
+	codeAllowedToolsDuplicate: toolCheck(func(_ *SkillContext, token toolToken, seen map[Tool]bool) string {
+		if token.problem != "" || !seen[token.tool] {
+			return ""
+		}
+		return fmt.Sprintf("Tool '%s' is listed more than once in 'allowed-tools'.", token.tool)
+	}),
+	codeAllowedToolsUnknown: toolCheck(func(ctx *SkillContext, token toolToken, _ map[Tool]bool) string {
+		known := ctx.Options.KnownTools
+		if len(known) == 0 || token.problem != "" || strings.HasPrefix(token.tool.Name, mcpToolPrefix) {
+			return ""
+		}
+		for _, name := range known {
+			if name == token.tool.Name {
+				return ""
+			}
+		}
+		return fmt.Sprintf("Unknown tool '%s' in 'allowed-tools'.", token.tool.Name)
+	}),
+}
@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAllowedTools(t *testing.T) {
+	cases := []struct {
+		value    string
+		tools    []Tool
+		problems int
+	}{
+		{"Read Grep", []Tool{{Name: "Read"}, {Name: "Grep"}}, 0},
+		{"  Bash(git status:*)\tRead ", []Tool{{Name: "Bash", Pattern: "git status:*"}, {Name: "Read"}}, 0},
+		{"Bash(echo (hi)) mcp__github__get_issue", []Tool{{Name: "Bash", Pattern: "echo (hi)"}, {Name: "mcp__github__get_issue"}}, 0},
+		{"Read, Grep", []Tool{{Name: "Read"}, {Name: "Grep"}}, 0},
+		{"Bash(git add a,b),Read", []Tool{{Name: "Bash", Pattern: "git add a,b"}, {Name: "Read"}}, 0},
+		{"Bash(git status Read", nil, 1},
+		{"Bash(a)(b) Read) Bash()", nil, 3},
+		{"1Read", nil, 1},
+	}
+	for _, tc := range cases {
+		tools := make([]Tool, 0)
+		problems := 0
+		for _, token := range parseAllowedTools(tc.value) {
+			if token.problem != "" {
+				problems++
+				continue
+			}
+			tools = append(tools, token.tool)
+		}
+		if tc.tools == nil {
+			tc.tools = []Tool{}
+		}
+		if !reflect.DeepEqual(tools, tc.tools) || problems != tc.problems {
+			t.Errorf("%q: expected %v with %d problems, got %v with %d", tc.value, tc.tools, tc.problems, tools, problems)
+		}
+	}
+}
+
+func TestAllowedToolsRules(t *testing.T) {
+	dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Tools.\nallowed-tools: Read Bash(git status:*) Grepp Read Bash(git diff \"Write\"\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{KnownTools: []string{"Read", "Bash"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make([]Finding, 0)
+	for _, finding := range append(result.Errors, result.Warnings...) {
+		got = append(got, Finding{Code: finding.Code, Line: finding.Line, Column: finding.Column, EndColumn: finding.EndColumn})
+	}
+	want := []Finding{
+		{Code: codeAllowedToolsUnknown, Line: 4, Column: 40, EndColumn: 45},
+		{Code: codeAllowedToolsDuplicate, Line: 4, Column: 46, EndColumn: 50},
+		{Code: codeAllowedToolsInvalid, Line: 4, Column: 51, EndColumn: 72},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+	wantTools := []Tool{{Name: "Read"}, {Name: "Bash", Pattern: "git status:*"}, {Name: "Grepp"}, {Name: "Read"}}
+	if !reflect.DeepEqual(result.AllowedTools, wantTools) {
+		t.Fatalf("expected tools %v, got %v", wantTools, result.AllowedTools)
+	}
+
+	for _, opts := range []Options{{}, {KnownTools: []string{"Read", "Bash", "Grepp"}}} {
+		result, err = ValidateSkill(dir, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, finding := range result.Warnings {
+			if finding.Code == codeAllowedToolsUnknown {
+				t.Fatalf("expected no unknown tools with known tools %v, got %#v", opts.KnownTools, finding)
+			}
+		}
+	}
+}
+
+func TestAllowedToolsQuotedValue(t *testing.T) {
+	dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Tools.\nallowed-tools: \"Read Nope\"\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{KnownTools: []string{"Read"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelWarning, codeAllowedToolsUnknown)
+	if finding := result.Warnings[0]; finding.Column != 22 || finding.EndColumn != 26 {
+		t.Fatalf("expected the finding at the tool inside the quotes, got %#v", finding)
+	}
+}
+
+func TestAllowedToolsCommas(t *testing.T) {
+	dir := writeSkill(t, "my-skill", "---\nname: my-skill\ndescription: Tools.\nallowed-tools: Read, Grep, Glob\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Valid || len(result.Errors) != 0 {
+		t.Fatalf("expected comma-separated tools to be valid, got %#v", result.Errors)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Code != codeAllowedToolsWhitespace {
+		t.Fatalf("expected only %s, got %#v", codeAllowedToolsWhitespace, result.Warnings)
+	}
+	wantTools := []Tool{{Name: "Read"}, {Name: "Grep"}, {Name: "Glob"}}
+	if !reflect.DeepEqual(result.AllowedTools, wantTools) {
+		t.Fatalf("expected tools %v, got %v", wantTools, result.AllowedTools)
+	}
+}
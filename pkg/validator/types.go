@@ -5,6 +5,27 @@ type Options struct {
 	NoWarn         bool
 	FollowSymlinks bool
 	CheckRefsExist bool
+	// Severity overrides the level of findings by code. LevelOff drops
+	// findings with that code entirely.
+	Severity map[string]FindingLevel
+	// MaxArchiveSize limits the total uncompressed size of an archive in
+	// bytes; zero means DefaultMaxArchiveSize.
+	MaxArchiveSize int64
+	// Portability enables the rules that flag YAML constructs which agent
+	// hosts with simpler parsers read differently.
+	Portability bool
+	// KnownTools lists the tool names allowed-tools may use without an
+	// ALLOWED_TOOLS_UNKNOWN warning; when empty, tool names are not checked.
+	KnownTools []string
+	// AllowedLicenses and DeniedLicenses are SPDX license identifiers. When
+	// either is set, a license expression that cannot be complied with using
+	// allowed, non-denied licenses is reported as LICENSE_NOT_ALLOWED. An
+	// empty allow list allows every license.
+	AllowedLicenses []string
+	DeniedLicenses  []string
+	// MetadataSchema, when set, declares the keys and values metadata must
+	// have.
+	MetadataSchema *MetadataSchema
 }
 
 type FindingLevel string
@@ -12,6 +33,7 @@ type FindingLevel string
 const (
 	LevelError   FindingLevel = "error"
 	LevelWarning FindingLevel = "warning"
+	LevelOff     FindingLevel = "off"
 )
 
 type Finding struct {
@@ -20,6 +42,32 @@ type Finding struct {
 	Message string       `json:"message"`
 	File    string       `json:"file,omitempty"`
 	Line    int          `json:"line,omitempty"`
+	// Column, EndLine and EndColumn complete the source range of findings
+	// located inside a file. Columns are 1-based; EndColumn is exclusive.
+	Column    int `json:"column,omitempty"`
+	EndLine   int `json:"endLine,omitempty"`
+	EndColumn int `json:"endColumn,omitempty"`
+	// Fixable reports whether FixSkill can repair the finding.
+	Fixable bool `json:"fixable,omitempty"`
+	// Suppressed marks findings silenced by an inline sklint-disable comment.
+	Suppressed bool `json:"suppressed,omitempty"`
+	// Suggestions are replacements that resolve the finding when applied
+	// together.
+	Suggestions []Suggestion `json:"suggestions,omitempty"`
+}
+
+// Suggestion replaces a range of a file with new text. The range is given
+// both as byte offsets and as 1-based lines and character columns; End and
+// EndColumn are exclusive. An empty range inserts the text.
+type Suggestion struct {
+	File      string `json:"file"`
+	Start     int    `json:"start"`
+	End       int    `json:"end"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"endLine"`
+	EndColumn int    `json:"endColumn"`
+	NewText   string `json:"newText"`
 }
 
 type Result struct {
@@ -27,4 +75,28 @@ type Result struct {
 	Valid    bool      `json:"valid"`
 	Errors   []Finding `json:"errors,omitempty"`
 	Warnings []Finding `json:"warnings,omitempty"`
+	// Suppressed holds findings silenced by inline comments. They do not
+	// affect Valid but are kept so audits can see them.
+	Suppressed []Finding `json:"suppressed,omitempty"`
+	// AllowedTools holds the valid entries of the allowed-tools field, so
+	// that the permissions a skill requests can be audited.
+	AllowedTools []Tool `json:"allowedTools,omitempty"`
+	// Archive is set when Path is a skill archive. The File of its findings
+	// then names an entry inside the archive rather than a file on disk.
+	Archive bool `json:"archive,omitempty"`
+	// Name and Description are the string values of the frontmatter fields,
+	// so that reports can list skills by what they declare.
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Disabled lists the codes the options turned off for this skill, so
+	// that reports can tell rules that passed from rules that did not run.
+	Disabled []string `json:"-"`
+}
+
+type Summary struct {
+	Skills   int `json:"skills"`
+	Valid    int `json:"valid"`
+	Invalid  int `json:"invalid"`
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -17,361 +18,472 @@ import (
 )
 
 const (
-	codePathNotFound           = "PATH_NOT_FOUND"
-	codePathNotDirectory       = "PATH_NOT_DIRECTORY"
-	codeSkillMDMissing         = "SKILL_MD_MISSING"
-	codeSkillMDNotFile         = "SKILL_MD_NOT_FILE"
-	codeSkillMDSymlink         = "SKILL_MD_SYMLINK"
-	codeSkillMDSymlinkInvalid  = "SKILL_MD_SYMLINK_INVALID"
-	codeSkillMDSymlinkEscapes  = "SKILL_MD_SYMLINK_ESCAPES_ROOT"
-	codeFrontmatterStart       = "FRONTMATTER_START_MISSING"
-	codeFrontmatterEnd         = "FRONTMATTER_END_MISSING"
-	codeFrontmatterEmpty       = "FRONTMATTER_EMPTY"
-	codeFrontmatterInvalidYAML = "FRONTMATTER_INVALID_YAML"
-	codeFrontmatterNotMapping  = "FRONTMATTER_NOT_MAPPING"
+	codePathNotFound            = "PATH_NOT_FOUND"
+	codePathNotDirectory        = "PATH_NOT_DIRECTORY"
+	codeSkillMDMissing          = "SKILL_MD_MISSING"
+	codeSkillMDNotFile          = "SKILL_MD_NOT_FILE"
+	codeSkillMDSymlink          = "SKILL_MD_SYMLINK"
+	codeSkillMDSymlinkInvalid   = "SKILL_MD_SYMLINK_INVALID"
+	codeSkillMDSymlinkEscapes   = "SKILL_MD_SYMLINK_ESCAPES_ROOT"
+	codeArchiveInvalid          = "ARCHIVE_INVALID"
+	codeArchiveTooLarge         = "ARCHIVE_TOO_LARGE"
+	codeArchiveUnsafePath       = "ARCHIVE_UNSAFE_PATH"
+	codeArchiveSymlink          = "ARCHIVE_SYMLINK"
+	codeArchiveRootNotSingleDir = "ARCHIVE_ROOT_NOT_SINGLE_DIR"
+	codeArchiveSkillMDMisplaced = "ARCHIVE_SKILL_MD_MISPLACED"
+	codeFrontmatterStart        = "FRONTMATTER_START_MISSING"
+	codeFrontmatterEnd          = "FRONTMATTER_END_MISSING"
+	codeFrontmatterEmpty        = "FRONTMATTER_EMPTY"
+	codeFrontmatterInvalidYAML  = "FRONTMATTER_INVALID_YAML"
+	codeFrontmatterNotMapping   = "FRONTMATTER_NOT_MAPPING"
+	codeFrontmatterDuplicateKey = "FRONTMATTER_DUPLICATE_KEY"
 
 	codeScriptsNotDir    = "SCRIPTS_NOT_DIRECTORY"
 	codeReferencesNotDir = "REFERENCES_NOT_DIRECTORY"
 	codeAssetsNotDir     = "ASSETS_NOT_DIRECTORY"
 
-	codeNameMissing             = "NAME_MISSING"
-	codeNameNotString           = "NAME_NOT_STRING"
-	codeNameTooShort            = "NAME_TOO_SHORT"
-	codeNameTooLong             = "NAME_TOO_LONG"
-	codeNameInvalidChars        = "NAME_INVALID_CHARS"
-	codeNameStartsWithHyphen    = "NAME_STARTS_WITH_HYPHEN"
-	codeNameEndsWithHyphen      = "NAME_ENDS_WITH_HYPHEN"
-	codeNameConsecutiveHyphens  = "NAME_CONSECUTIVE_HYPHENS"
-	codeNameMismatchDirectory   = "NAME_MISMATCH_DIRECTORY"
-	codeDescriptionMissing      = "DESCRIPTION_MISSING"
-	codeDescriptionNotString    = "DESCRIPTION_NOT_STRING"
-	codeDescriptionTooShort     = "DESCRIPTION_TOO_SHORT"
-	codeDescriptionTooLong      = "DESCRIPTION_TOO_LONG"
-	codeCompatibilityNotString  = "COMPATIBILITY_NOT_STRING"
-	codeCompatibilityTooShort   = "COMPATIBILITY_TOO_SHORT"
-	codeCompatibilityTooLong    = "COMPATIBILITY_TOO_LONG"
-	codeLicenseNotString        = "LICENSE_NOT_STRING"
-	codeMetadataNotObject       = "METADATA_NOT_OBJECT"
-	codeMetadataValueNotString  = "METADATA_VALUE_NOT_STRING"
-	codeAllowedToolsNotString   = "ALLOWED_TOOLS_NOT_STRING"
-	codeAllowedToolsEmpty       = "ALLOWED_TOOLS_EMPTY"
-
-	codeSkillMDTooLongLines   = "SKILL_MD_TOO_LONG_LINES"
-	codeSkillMDMissingBody    = "SKILL_MD_MISSING_BODY"
-	codeUnknownTopLevelKey    = "UNKNOWN_TOP_LEVEL_KEY"
-	codeScriptsDirEmpty       = "SCRIPTS_DIR_EMPTY"
-	codeReferencesDirEmpty    = "REFERENCES_DIR_EMPTY"
-	codeAssetsDirEmpty        = "ASSETS_DIR_EMPTY"
-	codeRefContainsDotDot     = "REF_CONTAINS_DOTDOT"
-	codeRefTooDeep            = "REF_TOO_DEEP"
-	codeRefMissingFile        = "REF_MISSING_FILE"
-	codeRefEscapesRoot        = "REF_ESCAPES_ROOT"
+	codeNameMissing            = "NAME_MISSING"
+	codeNameNotString          = "NAME_NOT_STRING"
+	codeNameTooShort           = "NAME_TOO_SHORT"
+	codeNameTooLong            = "NAME_TOO_LONG"
+	codeNameInvalidChars       = "NAME_INVALID_CHARS"
+	codeNameStartsWithHyphen   = "NAME_STARTS_WITH_HYPHEN"
+	codeNameEndsWithHyphen     = "NAME_ENDS_WITH_HYPHEN"
+	codeNameConsecutiveHyphens = "NAME_CONSECUTIVE_HYPHENS"
+	codeNameMismatchDirectory  = "NAME_MISMATCH_DIRECTORY"
+	codeDescriptionMissing     = "DESCRIPTION_MISSING"
+	codeDescriptionNotString   = "DESCRIPTION_NOT_STRING"
+	codeDescriptionTooShort    = "DESCRIPTION_TOO_SHORT"
+	codeDescriptionTooLong     = "DESCRIPTION_TOO_LONG"
+	codeCompatibilityNotString = "COMPATIBILITY_NOT_STRING"
+	codeCompatibilityTooShort  = "COMPATIBILITY_TOO_SHORT"
+	codeCompatibilityTooLong   = "COMPATIBILITY_TOO_LONG"
+	codeLicenseNotString       = "LICENSE_NOT_STRING"
+	codeLicenseNotAllowed      = "LICENSE_NOT_ALLOWED"
+	codeMetadataNotObject      = "METADATA_NOT_OBJECT"
+	codeMetadataValueNotString = "METADATA_VALUE_NOT_STRING"
+	codeMetadataKeyMissing     = "METADATA_KEY_MISSING"
+	codeMetadataValueInvalid   = "METADATA_VALUE_INVALID"
+	codeAllowedToolsNotString  = "ALLOWED_TOOLS_NOT_STRING"
+	codeAllowedToolsEmpty      = "ALLOWED_TOOLS_EMPTY"
+	codeAllowedToolsInvalid    = "ALLOWED_TOOLS_INVALID"
+
+	codeSkillMDTooLongLines    = "SKILL_MD_TOO_LONG_LINES"
+	codeSkillMDMissingBody     = "SKILL_MD_MISSING_BODY"
+	codeUnknownTopLevelKey     = "UNKNOWN_TOP_LEVEL_KEY"
+	codeMetadataKeyUnknown     = "METADATA_KEY_UNKNOWN"
+	codeLicenseInvalidSPDX     = "LICENSE_INVALID_SPDX"
+	codeAllowedToolsDuplicate  = "ALLOWED_TOOLS_DUPLICATE"
+	codeAllowedToolsUnknown    = "ALLOWED_TOOLS_UNKNOWN"
+	codeAllowedToolsWhitespace = "ALLOWED_TOOLS_WHITESPACE"
+	codeScriptsDirEmpty        = "SCRIPTS_DIR_EMPTY"
+	codeReferencesDirEmpty     = "REFERENCES_DIR_EMPTY"
+	codeAssetsDirEmpty         = "ASSETS_DIR_EMPTY"
+	codeRefContainsDotDot      = "REF_CONTAINS_DOTDOT"
+	codeRefTooDeep             = "REF_TOO_DEEP"
+	codeRefMissingFile         = "REF_MISSING_FILE"
+	codeRefEscapesRoot         = "REF_ESCAPES_ROOT"
+
+	codeYAMLAnchor            = "YAML_ANCHOR"
+	codeYAMLAlias             = "YAML_ALIAS"
+	codeYAMLExplicitTag       = "YAML_EXPLICIT_TAG"
+	codeYAMLMergeKey          = "YAML_MERGE_KEY"
+	codeYAML11Boolean         = "YAML_11_BOOLEAN"
+	codeYAMLMultipleDocuments = "YAML_MULTIPLE_DOCUMENTS"
+
+	codeSuppressionUnused = "SUPPRESSION_UNUSED"
 )
 
 var (
-	namePattern    = regexp.MustCompile(`^[a-z0-9-]+$`)
-	linkPattern    = regexp.MustCompile(`!?\[[^\]]*\]\(([^\s)]+)`)
+	namePattern     = regexp.MustCompile(`^[a-z0-9-]+$`)
+	linkPattern     = regexp.MustCompile(`!?\[[^\]]*\]\(([^\s)]+)`)
 	plainRefPattern = regexp.MustCompile(`(^|\s)(scripts|references|assets)/[^\s)]+`)
 )
 
 var knownKeys = map[string]struct{}{
-	"name":         {},
-	"description":  {},
-	"license":      {},
+	"name":          {},
+	"description":   {},
+	"license":       {},
 	"compatibility": {},
-	"metadata":     {},
+	"metadata":      {},
 	"allowed-tools": {},
 }
 
+// KnownKeys returns the top-level frontmatter keys defined by the
+// specification, sorted.
+func KnownKeys() []string {
+	keys := make([]string, 0, len(knownKeys))
+	for key := range knownKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidateSkill validates the skill directory at path on disk, or the
+// archive at path when IsArchive reports it as one.
 func ValidateSkill(path string, opts Options) (Result, error) {
-	result := Result{}
+	if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() && IsArchive(path) {
+		return ValidateArchive(path, opts)
+	}
+	result, _, err := validateSkill(path, nil, opts)
+	return result, err
+}
+
+// ValidateContent validates the skill directory at path as if its SKILL.md
+// contained content, which lets editors check unsaved buffers. The SKILL.md
+// on disk does not need to exist.
+func ValidateContent(path string, content []byte, opts Options) (Result, error) {
+	if content == nil {
+		content = []byte{}
+	}
+	result, _, err := validateSkill(path, content, opts)
+	return result, err
+}
+
+// ValidateFS validates the skill directory root of fsys, such as an
+// embed.FS or an archive, with the same checks as ValidateSkill. Symlinks
+// are reported when fsys implements ReadLinkFS. root must satisfy
+// fs.ValidPath; when it is ".", the directory name is unknown and the name
+// is not compared against it.
+func ValidateFS(fsys fs.FS, root string, opts Options) (Result, error) {
+	dirName := path.Base(root)
+	if root == "." {
+		dirName = ""
+	}
+	result, _, err := validateFS(fsys, root, root, dirName, nil, opts)
+	return result, err
+}
+
+// skillSource is the parsed SKILL.md of a skill whose frontmatter is a valid
+// mapping; it is what FixSkill edits.
+type skillSource struct {
+	file        string
+	content     []byte
+	frontmatter parse.Frontmatter
+	root        *yaml.Node
+	dirName     string
+}
+
+// validateSkill validates a skill on disk through ValidateFS. SKILL.md is
+// read from disk unless content is non-nil, and the source it returns names
+// the real path of SKILL.md.
+func validateSkill(path string, content []byte, opts Options) (Result, *skillSource, error) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
-		return result, err
+		return Result{}, nil, err
 	}
+	result, src, err := validateFS(newOSFS(absPath), ".", path, filepath.Base(absPath), content, opts)
 	result.Path = absPath
+	if src != nil {
+		src.file = filepath.Join(absPath, filepath.FromSlash(src.file))
+		if resolved, err := filepath.EvalSymlinks(src.file); err == nil {
+			src.file = resolved
+		}
+	}
+	return result, src, err
+}
+
+// validateFS validates the skill directory root of fsys. display is how the
+// directory is named in messages and dirName is what the frontmatter name
+// must match, if known. SKILL.md is read from fsys unless content is non-nil.
+func validateFS(fsys fs.FS, root, display, dirName string, content []byte, opts Options) (Result, *skillSource, error) {
+	result := Result{Path: root}
+	if !fs.ValidPath(root) {
+		return result, nil, &fs.PathError{Op: "validate", Path: root, Err: fs.ErrInvalid}
+	}
 
-	info, err := os.Stat(absPath)
+	info, err := fs.Stat(fsys, root)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
-			addError(&result, codePathNotFound, fmt.Sprintf("Path '%s' does not exist.", path), "", 0)
-			finalizeResult(&result, opts)
-			return result, nil
+			addError(&result, codePathNotFound, fmt.Sprintf("Path '%s' does not exist.", display), "", span{})
+			finalizeResult(&result, opts, nil)
+			return result, nil, nil
 		}
-		return result, err
+		return result, nil, err
 	}
 	if !info.IsDir() {
-		addError(&result, codePathNotDirectory, fmt.Sprintf("Path '%s' is not a directory.", path), "", 0)
-		finalizeResult(&result, opts)
-		return result, nil
+		addError(&result, codePathNotDirectory, fmt.Sprintf("Path '%s' is not a directory.", display), "", span{})
+		finalizeResult(&result, opts, nil)
+		return result, nil, nil
 	}
 
-	checkOptionalDir(absPath, "scripts", codeScriptsNotDir, codeScriptsDirEmpty, &result, opts)
-	checkOptionalDir(absPath, "references", codeReferencesNotDir, codeReferencesDirEmpty, &result, opts)
-	checkOptionalDir(absPath, "assets", codeAssetsNotDir, codeAssetsDirEmpty, &result, opts)
+	checkOptionalDir(fsys, root, "scripts", codeScriptsNotDir, codeScriptsDirEmpty, &result)
+	checkOptionalDir(fsys, root, "references", codeReferencesNotDir, codeReferencesDirEmpty, &result)
+	checkOptionalDir(fsys, root, "assets", codeAssetsNotDir, codeAssetsDirEmpty, &result)
 
-	skillPath := filepath.Join(absPath, "SKILL.md")
-	skillInfo, err := os.Lstat(skillPath)
+	skillPath := path.Join(root, "SKILL.md")
+	if content != nil {
+		return validateContent(result, fsys, root, dirName, skillPath, content, opts)
+	}
+	skillInfo, err := lstat(fsys, skillPath)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
-			addError(&result, codeSkillMDMissing, "SKILL.md is required.", "SKILL.md", 0)
-			finalizeResult(&result, opts)
-			return result, nil
+			addError(&result, codeSkillMDMissing, "SKILL.md is required.", "SKILL.md", span{})
+			finalizeResult(&result, opts, nil)
+			return result, nil, nil
 		}
-		return result, err
+		return result, nil, err
 	}
 	if skillInfo.IsDir() {
-		addError(&result, codeSkillMDNotFile, "SKILL.md must be a file, not a directory.", "SKILL.md", 0)
-		finalizeResult(&result, opts)
-		return result, nil
+		addError(&result, codeSkillMDNotFile, "SKILL.md must be a file, not a directory.", "SKILL.md", span{})
+		finalizeResult(&result, opts, nil)
+		return result, nil, nil
 	}
 
 	resolvedSkillPath := skillPath
-	if skillInfo.Mode()&os.ModeSymlink != 0 {
-		addWarning(&result, opts, codeSkillMDSymlink, "SKILL.md is a symlink.", "SKILL.md", 0)
-		resolved, err := filepath.EvalSymlinks(skillPath)
+	if skillInfo.Mode()&fs.ModeSymlink != 0 {
+		addWarning(&result, codeSkillMDSymlink, "SKILL.md is a symlink.", "SKILL.md", span{})
+		links, ok := fsys.(ReadLinkFS)
+		if !ok {
+			addError(&result, codeSkillMDSymlinkInvalid, "SKILL.md symlink cannot be resolved.", "SKILL.md", span{})
+			finalizeResult(&result, opts, nil)
+			return result, nil, nil
+		}
+		resolved, escapes, err := resolveLink(links, root, skillPath)
+		if err == nil && escapes {
+			_, err = fs.Stat(fsys, skillPath)
+		}
 		if err != nil {
-			addError(&result, codeSkillMDSymlinkInvalid, "SKILL.md symlink cannot be resolved.", "SKILL.md", 0)
-			finalizeResult(&result, opts)
-			return result, nil
+			addError(&result, codeSkillMDSymlinkInvalid, "SKILL.md symlink cannot be resolved.", "SKILL.md", span{})
+			finalizeResult(&result, opts, nil)
+			return result, nil, nil
+		}
+		if escapes && !opts.FollowSymlinks {
+			addError(&result, codeSkillMDSymlinkEscapes, "SKILL.md symlink resolves outside the skill directory.", "SKILL.md", span{})
+			finalizeResult(&result, opts, nil)
+			return result, nil, nil
 		}
-		if !opts.FollowSymlinks && !isWithinRoot(absPath, resolved) {
-			addError(&result, codeSkillMDSymlinkEscapes, "SKILL.md symlink resolves outside the skill directory.", "SKILL.md", 0)
-			finalizeResult(&result, opts)
-			return result, nil
+		if !escapes {
+			resolvedSkillPath = resolved
 		}
-		resolvedSkillPath = resolved
 	}
 
-	content, err := os.ReadFile(resolvedSkillPath)
+	content, err = fs.ReadFile(fsys, resolvedSkillPath)
 	if err != nil {
-		return result, err
+		return result, nil, err
 	}
+	return validateContent(result, fsys, root, dirName, resolvedSkillPath, content, opts)
+}
+
+// validateContent checks the SKILL.md content of the skill directory dir of
+// fsys; file is where the content was read from.
+func validateContent(result Result, fsys fs.FS, dir, dirName, file string, content []byte, opts Options) (Result, *skillSource, error) {
+	lines := newLineIndex(content)
 
 	frontmatter, err := parse.ParseFrontmatter(bytes.NewReader(content))
 	if err != nil {
 		switch err {
 		case parse.ErrFrontmatterStartMissing:
-			addError(&result, codeFrontmatterStart, "SKILL.md must begin with '---' frontmatter delimiter.", "SKILL.md", 1)
+			addError(&result, codeFrontmatterStart, "SKILL.md must begin with '---' frontmatter delimiter.", "SKILL.md", lines.lineSpan(1))
+			result.Errors[len(result.Errors)-1].Suggestions = suggestions("SKILL.md", lines, optionalEdit(frontmatterStartEdit(lines)))
 		case parse.ErrFrontmatterEndMissing:
-			addError(&result, codeFrontmatterEnd, "SKILL.md frontmatter must end with '---' delimiter.", "SKILL.md", 0)
+			addError(&result, codeFrontmatterEnd, "SKILL.md frontmatter must end with '---' delimiter.", "SKILL.md", lines.lineSpan(1))
+			result.Errors[len(result.Errors)-1].Suggestions = suggestions("SKILL.md", lines, optionalEdit(frontmatterEndEdit(lines)))
 		case parse.ErrFrontmatterEmpty:
-			addError(&result, codeFrontmatterEmpty, "Frontmatter must contain at least one key.", "SKILL.md", 0)
+			addError(&result, codeFrontmatterEmpty, "Frontmatter must contain at least one key.", "SKILL.md", lines.lineSpan(1))
 		default:
-			return result, err
+			return result, nil, err
 		}
-		finalizeResult(&result, opts)
-		return result, nil
+		finalizeResult(&result, opts, nil)
+		return result, nil, nil
 	}
 
 	var node yaml.Node
 	if err := yaml.Unmarshal([]byte(frontmatter.YAML), &node); err != nil {
-		addError(&result, codeFrontmatterInvalidYAML, fmt.Sprintf("Frontmatter YAML is invalid: %s", err.Error()), "SKILL.md", 0)
-		finalizeResult(&result, opts)
-		return result, nil
+		addError(&result, codeFrontmatterInvalidYAML, fmt.Sprintf("Frontmatter YAML is invalid: %s", err.Error()), "SKILL.md", lines.yamlErrorSpan(frontmatter.YAMLStartLine, err))
+		finalizeResult(&result, opts, nil)
+		return result, nil, nil
 	}
 
+	yamlSpan := lines.rangeSpan(frontmatter.YAMLStartLine, frontmatter.BodyStartLine-2)
 	root, err := mappingRoot(&node)
 	if err != nil {
-		addError(&result, codeFrontmatterNotMapping, "Frontmatter YAML must be a mapping/object.", "SKILL.md", 0)
-		finalizeResult(&result, opts)
-		return result, nil
+		addError(&result, codeFrontmatterNotMapping, "Frontmatter YAML must be a mapping/object.", "SKILL.md", yamlSpan)
+		finalizeResult(&result, opts, nil)
+		return result, nil, nil
 	}
 	if len(root.Content) == 0 {
-		addError(&result, codeFrontmatterEmpty, "Frontmatter must contain at least one key.", "SKILL.md", 0)
-		finalizeResult(&result, opts)
-		return result, nil
+		addError(&result, codeFrontmatterEmpty, "Frontmatter must contain at least one key.", "SKILL.md", yamlSpan)
+		finalizeResult(&result, opts, nil)
+		return result, nil, nil
 	}
 
-	keyLines := mapKeyLines(root, frontmatter.YAMLStartLine)
-
-	var data map[string]any
-	if err := yaml.Unmarshal([]byte(frontmatter.YAML), &data); err != nil {
-		addError(&result, codeFrontmatterInvalidYAML, fmt.Sprintf("Frontmatter YAML is invalid: %s", err.Error()), "SKILL.md", 0)
-		finalizeResult(&result, opts)
-		return result, nil
-	}
-
-	validateName(&result, data, keyLines, filepath.Base(absPath))
-	validateDescription(&result, data, keyLines)
-	validateCompatibility(&result, data, keyLines)
-	validateLicense(&result, data, keyLines)
-	validateMetadata(&result, data, keyLines)
-	validateAllowedTools(&result, data, keyLines)
-
-	if !opts.NoWarn {
-		unknownKeys := collectUnknownKeys(root)
-		if len(unknownKeys) > 0 {
-			addWarning(&result, opts, codeUnknownTopLevelKey, fmt.Sprintf("Unknown top-level keys: %s", strings.Join(unknownKeys, ", ")), "SKILL.md", 0)
-		}
+	duplicates := duplicateKeys(root, "")
+	for _, dup := range duplicates {
+		addError(&result, codeFrontmatterDuplicateKey,
+			fmt.Sprintf("Frontmatter key '%s' is already defined on line %d.", dup.path, frontmatter.YAMLStartLine+dup.first.Line-1),
+			"SKILL.md", lines.nodeSpan(frontmatter.YAMLStartLine, dup.key))
 	}
 
-	if frontmatter.LineCount > 500 {
-		addWarning(&result, opts, codeSkillMDTooLongLines, fmt.Sprintf("SKILL.md is %d lines; recommended under 500 lines.", frontmatter.LineCount), "SKILL.md", 0)
-	}
-	if strings.TrimSpace(frontmatter.Body) == "" {
-		addWarning(&result, opts, codeSkillMDMissingBody, "SKILL.md body is empty.", "SKILL.md", 0)
+	var data map[string]any
+	if len(duplicates) > 0 {
+		// Decoding the text would fail on the repeated keys, so the other
+		// rules run on the first definition of each key.
+		root = withoutDuplicates(root)
+		err = root.Decode(&data)
+	} else {
+		err = yaml.Unmarshal([]byte(frontmatter.YAML), &data)
 	}
+	if err != nil {
+		addError(&result, codeFrontmatterInvalidYAML, fmt.Sprintf("Frontmatter YAML is invalid: %s", err.Error()), "SKILL.md", lines.yamlErrorSpan(frontmatter.YAMLStartLine, err))
+		finalizeResult(&result, opts, nil)
+		return result, nil, nil
+	}
+
+	keys := mapKeySpans(root, lines, frontmatter.YAMLStartLine)
+
+	runRules(&result, &SkillContext{
+		FS:            fsys,
+		Dir:           dir,
+		DirName:       dirName,
+		Options:       opts,
+		Content:       content,
+		Frontmatter:   data,
+		Node:          root,
+		Body:          frontmatter.Body,
+		BodyStartLine: frontmatter.BodyStartLine,
+		source:        frontmatter,
+		lines:         lines,
+		keys:          keys,
+	})
 
-	scanReferences(absPath, frontmatter.Body, &result, opts)
-
-	finalizeResult(&result, opts)
-	return result, nil
-}
+	result.Name, _ = data["name"].(string)
+	result.Description, _ = data["description"].(string)
+	result.AllowedTools = allowedTools(data)
 
-func validateName(result *Result, data map[string]any, lines map[string]int, dirName string) {
-	value, ok := data["name"]
-	if !ok {
-		addError(result, codeNameMissing, "Frontmatter 'name' is required.", "SKILL.md", 0)
-		return
-	}
-	name, ok := value.(string)
-	if !ok {
-		addError(result, codeNameNotString, "Frontmatter 'name' must be a string.", "SKILL.md", lineFor(lines, "name"))
-		return
-	}
-	if len(name) < 1 {
-		addError(result, codeNameTooShort, "Frontmatter 'name' must be at least 1 character.", "SKILL.md", lineFor(lines, "name"))
-	}
-	if len(name) > 64 {
-		addError(result, codeNameTooLong, "Frontmatter 'name' must be at most 64 characters.", "SKILL.md", lineFor(lines, "name"))
-	}
-	if !namePattern.MatchString(name) {
-		addError(result, codeNameInvalidChars, "Frontmatter 'name' must use lowercase letters, digits, and hyphens only.", "SKILL.md", lineFor(lines, "name"))
+	src := &skillSource{
+		file:        file,
+		content:     content,
+		frontmatter: frontmatter,
+		root:        root,
+		dirName:     dirName,
 	}
-	if strings.HasPrefix(name, "-") {
-		addError(result, codeNameStartsWithHyphen, "Frontmatter 'name' must not start with '-'.", "SKILL.md", lineFor(lines, "name"))
-	}
-	if strings.HasSuffix(name, "-") {
-		addError(result, codeNameEndsWithHyphen, "Frontmatter 'name' must not end with '-'.", "SKILL.md", lineFor(lines, "name"))
-	}
-	if strings.Contains(name, "--") {
-		addError(result, codeNameConsecutiveHyphens, "Frontmatter 'name' must not contain consecutive hyphens.", "SKILL.md", lineFor(lines, "name"))
-	}
-	if name != dirName {
-		addError(result, codeNameMismatchDirectory, fmt.Sprintf("Frontmatter name '%s' must match directory name '%s'.", name, dirName), "SKILL.md", lineFor(lines, "name"))
-	}
-}
+	markFixable(&result, planFixes(src), lines)
 
-func validateDescription(result *Result, data map[string]any, lines map[string]int) {
-	value, ok := data["description"]
-	if !ok {
-		addError(result, codeDescriptionMissing, "Frontmatter 'description' is required.", "SKILL.md", 0)
-		return
-	}
-	desc, ok := value.(string)
-	if !ok {
-		addError(result, codeDescriptionNotString, "Frontmatter 'description' must be a string.", "SKILL.md", lineFor(lines, "description"))
-		return
-	}
-	if len(desc) < 1 {
-		addError(result, codeDescriptionTooShort, "Frontmatter 'description' must be at least 1 character.", "SKILL.md", lineFor(lines, "description"))
-	}
-	if len(desc) > 1024 {
-		addError(result, codeDescriptionTooLong, "Frontmatter 'description' must be at most 1024 characters.", "SKILL.md", lineFor(lines, "description"))
-	}
+	finalizeResult(&result, opts, parseSuppressions(frontmatter, &node))
+	return result, src, nil
 }
 
-func validateCompatibility(result *Result, data map[string]any, lines map[string]int) {
-	value, ok := data["compatibility"]
-	if !ok {
-		return
-	}
-	comp, ok := value.(string)
-	if !ok {
-		addError(result, codeCompatibilityNotString, "Frontmatter 'compatibility' must be a string.", "SKILL.md", lineFor(lines, "compatibility"))
-		return
-	}
-	if len(comp) < 1 {
-		addError(result, codeCompatibilityTooShort, "Frontmatter 'compatibility' must be at least 1 character.", "SKILL.md", lineFor(lines, "compatibility"))
+func mappingRoot(node *yaml.Node) (*yaml.Node, error) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
 	}
-	if len(comp) > 500 {
-		addError(result, codeCompatibilityTooLong, "Frontmatter 'compatibility' must be at most 500 characters.", "SKILL.md", lineFor(lines, "compatibility"))
+	if node.Kind != yaml.MappingNode {
+		return nil, errors.New("not mapping")
 	}
+	return node, nil
 }
 
-func validateLicense(result *Result, data map[string]any, lines map[string]int) {
-	value, ok := data["license"]
-	if !ok {
-		return
-	}
-	if _, ok := value.(string); !ok {
-		addError(result, codeLicenseNotString, "Frontmatter 'license' must be a string.", "SKILL.md", lineFor(lines, "license"))
-	}
+// duplicateKey is a mapping key that repeats an earlier key of the same
+// mapping.
+type duplicateKey struct {
+	path  string
+	key   *yaml.Node
+	first *yaml.Node
 }
 
-func validateMetadata(result *Result, data map[string]any, lines map[string]int) {
-	value, ok := data["metadata"]
-	if !ok {
-		return
-	}
-	switch typed := value.(type) {
-	case map[string]any:
-		for _, v := range typed {
-			if _, ok := v.(string); !ok {
-				addError(result, codeMetadataValueNotString, "Frontmatter 'metadata' values must be strings.", "SKILL.md", lineFor(lines, "metadata"))
-				return
-			}
-		}
-	case map[any]any:
-		for k, v := range typed {
-			if _, ok := k.(string); !ok {
-				addError(result, codeMetadataNotObject, "Frontmatter 'metadata' must be an object with string keys.", "SKILL.md", lineFor(lines, "metadata"))
-				return
+// duplicateKeys finds repeated keys in node and every mapping nested in it.
+// Decoding into a map would reject them with a single error naming only the
+// first, so the node tree is walked to report each one at its own line.
+func duplicateKeys(node *yaml.Node, prefix string) []duplicateKey {
+	duplicates := make([]duplicateKey, 0)
+	switch node.Kind {
+	case yaml.MappingNode:
+		seen := make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			path := keyNode.Value
+			if prefix != "" {
+				path = prefix + "." + keyNode.Value
 			}
-			if _, ok := v.(string); !ok {
-				addError(result, codeMetadataValueNotString, "Frontmatter 'metadata' values must be strings.", "SKILL.md", lineFor(lines, "metadata"))
-				return
+			if keyNode.Kind == yaml.ScalarNode {
+				if first, ok := seen[keyNode.Value]; ok {
+					duplicates = append(duplicates, duplicateKey{path: path, key: keyNode, first: first})
+				} else {
+					seen[keyNode.Value] = keyNode
+				}
 			}
+			duplicates = append(duplicates, duplicateKeys(node.Content[i+1], path)...)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			duplicates = append(duplicates, duplicateKeys(item, fmt.Sprintf("%s[%d]", prefix, i))...)
 		}
-	default:
-		addError(result, codeMetadataNotObject, "Frontmatter 'metadata' must be an object.", "SKILL.md", lineFor(lines, "metadata"))
 	}
+	return duplicates
 }
 
-func validateAllowedTools(result *Result, data map[string]any, lines map[string]int) {
-	value, ok := data["allowed-tools"]
-	if !ok {
-		return
-	}
-	tools, ok := value.(string)
-	if !ok {
-		addError(result, codeAllowedToolsNotString, "Frontmatter 'allowed-tools' must be a string.", "SKILL.md", lineFor(lines, "allowed-tools"))
-		return
-	}
-	if strings.TrimSpace(tools) == "" {
-		addError(result, codeAllowedToolsEmpty, "Frontmatter 'allowed-tools' must not be empty.", "SKILL.md", lineFor(lines, "allowed-tools"))
-		return
+// withoutDuplicates returns a copy of node in which every mapping keeps only
+// the first definition of a repeated key. The nodes themselves are shared, so
+// positions and comments stay those of the original tree.
+func withoutDuplicates(node *yaml.Node) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		copied := *node
+		copied.Content = make([]*yaml.Node, 0, len(node.Content))
+		seen := make(map[string]bool)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			if keyNode.Kind == yaml.ScalarNode {
+				if seen[keyNode.Value] {
+					continue
+				}
+				seen[keyNode.Value] = true
+			}
+			copied.Content = append(copied.Content, keyNode, withoutDuplicates(node.Content[i+1]))
+		}
+		return &copied
+	case yaml.SequenceNode:
+		copied := *node
+		copied.Content = make([]*yaml.Node, 0, len(node.Content))
+		for _, item := range node.Content {
+			copied.Content = append(copied.Content, withoutDuplicates(item))
+		}
+		return &copied
 	}
-	_ = strings.Fields(tools)
+	return node
 }
 
-func mappingRoot(node *yaml.Node) (*yaml.Node, error) {
-	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
-		node = node.Content[0]
-	}
-	if node.Kind != yaml.MappingNode {
-		return nil, errors.New("not mapping")
-	}
-	return node, nil
+// keySpans locates the top-level keys of the frontmatter and their values.
+type keySpans struct {
+	keys   map[string]span
+	values map[string]span
+	// frontmatter is the opening delimiter, used for keys that are missing.
+	frontmatter span
 }
 
-func mapKeyLines(node *yaml.Node, offset int) map[string]int {
-	lines := make(map[string]int)
+func mapKeySpans(node *yaml.Node, lines lineIndex, offset int) keySpans {
+	spans := keySpans{
+		keys:        make(map[string]span),
+		values:      make(map[string]span),
+		frontmatter: lines.lineSpan(1),
+	}
 	for i := 0; i < len(node.Content)-1; i += 2 {
 		keyNode := node.Content[i]
 		if keyNode.Kind == yaml.ScalarNode {
-			lines[keyNode.Value] = keyNode.Line + offset - 1
+			spans.keys[keyNode.Value] = lines.nodeSpan(offset, keyNode)
+			spans.values[keyNode.Value] = lines.nodeSpan(offset, node.Content[i+1])
 		}
 	}
-	return lines
+	return spans
+}
+
+func (s keySpans) key(key string) span {
+	if at, ok := s.keys[key]; ok {
+		return at
+	}
+	return s.frontmatter
 }
 
-func lineFor(lines map[string]int, key string) int {
-	if line, ok := lines[key]; ok {
-		return line
+// value covers the value of key, falling back to the key itself when the
+// value starts on a later line, as block scalars and nested mappings do.
+func (s keySpans) value(key string) span {
+	at, ok := s.values[key]
+	if !ok || at.line != s.keys[key].line {
+		return s.key(key)
 	}
-	return 0
+	return at
 }
 
 func collectUnknownKeys(node *yaml.Node) []string {
@@ -390,109 +502,22 @@ func collectUnknownKeys(node *yaml.Node) []string {
 	return unknown
 }
 
-func checkOptionalDir(root, name, errCode, warnCode string, result *Result, opts Options) {
-	path := filepath.Join(root, name)
-	info, err := os.Stat(path)
+func checkOptionalDir(fsys fs.FS, root, name, errCode, warnCode string, result *Result) {
+	dir := path.Join(root, name)
+	info, err := fs.Stat(fsys, dir)
 	if err != nil {
-		if errors.Is(err, fs.ErrNotExist) {
-			return
-		}
 		return
 	}
 	if !info.IsDir() {
-		addError(result, errCode, fmt.Sprintf("%s must be a directory if present.", name), name, 0)
+		addError(result, errCode, fmt.Sprintf("%s must be a directory if present.", name), name, span{})
 		return
 	}
-	entries, err := os.ReadDir(path)
+	entries, err := fs.ReadDir(fsys, dir)
 	if err != nil {
 		return
 	}
 	if len(entries) == 0 {
-		addWarning(result, opts, warnCode, fmt.Sprintf("%s directory is empty.", name), name, 0)
-	}
-}
-
-func scanReferences(root, body string, result *Result, opts Options) {
-	if opts.NoWarn {
-		return
-	}
-	seen := make(map[string]struct{})
-
-	for _, match := range linkPattern.FindAllStringSubmatch(body, -1) {
-		if len(match) < 2 {
-			continue
-		}
-		ref := strings.TrimSpace(match[1])
-		collectRef(ref, seen)
-	}
-
-	lines := strings.Split(body, "\n")
-	for _, line := range lines {
-		matches := plainRefPattern.FindAllString(line, -1)
-		for _, match := range matches {
-			ref := strings.TrimSpace(match)
-			ref = strings.TrimLeft(ref, " \t")
-			ref = strings.TrimRight(ref, ".,;:)")
-			collectRef(ref, seen)
-		}
-	}
-
-	for ref := range seen {
-		checkRef(root, ref, result, opts)
-	}
-}
-
-func collectRef(ref string, seen map[string]struct{}) {
-	if ref == "" {
-		return
-	}
-	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
-		return
-	}
-	if strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, "#") {
-		return
-	}
-	seen[ref] = struct{}{}
-}
-
-func checkRef(root, ref string, result *Result, opts Options) {
-	if hasDotDot(ref) {
-		addWarning(result, opts, codeRefContainsDotDot, fmt.Sprintf("Reference '%s' contains '..' path segments.", ref), "SKILL.md", 0)
-	}
-	trimmed := strings.TrimPrefix(ref, "./")
-	if strings.Count(trimmed, "/") > 1 {
-		addWarning(result, opts, codeRefTooDeep, fmt.Sprintf("Reference '%s' is nested deeper than one level.", ref), "SKILL.md", 0)
-	}
-
-	if !opts.CheckRefsExist {
-		return
-	}
-
-	refPath := filepath.FromSlash(ref)
-	target := filepath.Clean(filepath.Join(root, refPath))
-	if !isWithinRoot(root, target) {
-		addWarning(result, opts, codeRefEscapesRoot, fmt.Sprintf("Reference '%s' resolves outside the skill directory.", ref), "SKILL.md", 0)
-		return
-	}
-
-	info, err := os.Lstat(target)
-	if err != nil {
-		if errors.Is(err, fs.ErrNotExist) {
-			addWarning(result, opts, codeRefMissingFile, fmt.Sprintf("Reference '%s' does not exist.", ref), "SKILL.md", 0)
-		}
-		return
-	}
-
-	if info.Mode()&os.ModeSymlink != 0 && !opts.FollowSymlinks {
-		resolved, err := filepath.EvalSymlinks(target)
-		if err != nil {
-			addWarning(result, opts, codeRefMissingFile, fmt.Sprintf("Reference '%s' could not be resolved.", ref), "SKILL.md", 0)
-			return
-		}
-		if !isWithinRoot(root, resolved) {
-			addWarning(result, opts, codeRefEscapesRoot, fmt.Sprintf("Reference '%s' resolves outside the skill directory.", ref), "SKILL.md", 0)
-			return
-		}
+		addWarning(result, warnCode, fmt.Sprintf("%s directory is empty.", name), name, span{})
 	}
 }
 
@@ -505,53 +530,80 @@ func hasDotDot(path string) bool {
 	return false
 }
 
-func isWithinRoot(root, target string) bool {
-	rel, err := filepath.Rel(root, target)
-	if err != nil {
-		return false
-	}
-	if rel == "." {
-		return true
-	}
-	sep := string(os.PathSeparator)
-	if rel == ".." || strings.HasPrefix(rel, ".."+sep) {
-		return false
-	}
-	return true
+func addError(result *Result, code, message, file string, at span) {
+	result.Errors = append(result.Errors, newFinding(LevelError, code, message, file, at))
 }
 
-func addError(result *Result, code, message, file string, line int) {
-	result.Errors = append(result.Errors, Finding{
-		Level:   LevelError,
-		Code:    code,
-		Message: message,
-		File:    file,
-		Line:    line,
-	})
+func addWarning(result *Result, code, message, file string, at span) {
+	result.Warnings = append(result.Warnings, newFinding(LevelWarning, code, message, file, at))
 }
 
-func addWarning(result *Result, opts Options, code, message, file string, line int) {
-	if opts.NoWarn {
-		return
+func newFinding(level FindingLevel, code, message, file string, at span) Finding {
+	return Finding{
+		Level:     level,
+		Code:      code,
+		Message:   message,
+		File:      file,
+		Line:      at.line,
+		Column:    at.column,
+		EndLine:   at.endLine,
+		EndColumn: at.endColumn,
 	}
-	result.Warnings = append(result.Warnings, Finding{
-		Level:   LevelWarning,
-		Code:    code,
-		Message: message,
-		File:    file,
-		Line:    line,
-	})
 }
 
-func finalizeResult(result *Result, opts Options) {
+func finalizeResult(result *Result, opts Options, suppressions []suppression) {
+	applySeverity(result, opts)
+	applySuppressions(result, suppressions, opts)
+	if opts.NoWarn {
+		result.Warnings = nil
+		result.Suppressed = dropWarnings(result.Suppressed)
+	}
 	sortFindings(result.Errors)
 	sortFindings(result.Warnings)
+	sortFindings(result.Suppressed)
 
 	valid := len(result.Errors) == 0
 	if opts.Strict && len(result.Warnings) > 0 {
 		valid = false
 	}
 	result.Valid = valid
+	result.Disabled = DisabledCodes(opts)
+}
+
+// applySeverity moves findings between errors and warnings according to
+// opts.Severity, dropping those whose code is turned off. It runs before
+// NoWarn so that a warning promoted to an error is still reported.
+func applySeverity(result *Result, opts Options) {
+	if len(opts.Severity) == 0 {
+		return
+	}
+	findings := append(result.Errors, result.Warnings...)
+	result.Errors = nil
+	result.Warnings = nil
+	for _, finding := range findings {
+		if level, ok := opts.Severity[finding.Code]; ok {
+			finding.Level = level
+		}
+		switch finding.Level {
+		case LevelError:
+			result.Errors = append(result.Errors, finding)
+		case LevelWarning:
+			result.Warnings = append(result.Warnings, finding)
+		}
+	}
+}
+
+func dropWarnings(findings []Finding) []Finding {
+	kept := findings[:0]
+	for _, finding := range findings {
+		if finding.Level != LevelWarning {
+			kept = append(kept, finding)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
 }
 
 func sortFindings(findings []Finding) {
@@ -562,14 +614,20 @@ func sortFindings(findings []Finding) {
 		li := findings[i].Line
 		lj := findings[j].Line
 		if li == 0 {
-			li = 1<<30
+			li = 1 << 30
 		}
 		if lj == 0 {
-			lj = 1<<30
+			lj = 1 << 30
 		}
 		if li != lj {
 			return li < lj
 		}
-		return findings[i].Code < findings[j].Code
+		if findings[i].Column != findings[j].Column {
+			return findings[i].Column < findings[j].Column
+		}
+		if findings[i].Code != findings[j].Code {
+			return findings[i].Code < findings[j].Code
+		}
+		return findings[i].Message < findings[j].Message
 	})
 }
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"testing"
 )
@@ -26,6 +27,9 @@ func TestValidMinimal(t *testing.T) {
 	if len(result.Errors) != 0 {
 		t.Fatalf("expected no errors, got %d", len(result.Errors))
 	}
+	if result.Name != "valid-minimal" || result.Description != "A valid minimal skill." {
+		t.Fatalf("unexpected name and description: %q, %q", result.Name, result.Description)
+	}
 }
 
 func TestMissingSkillMD(t *testing.T) {
@@ -45,6 +49,7 @@ func TestFrontmatterErrors(t *testing.T) {
 		{"invalid-frontmatter-missing-end", codeFrontmatterEnd},
 		{"invalid-frontmatter-invalid-yaml", codeFrontmatterInvalidYAML},
 		{"invalid-frontmatter-not-mapping", codeFrontmatterNotMapping},
+		{"invalid-frontmatter-duplicate-key", codeFrontmatterDuplicateKey},
 	}
 	for _, tc := range cases {
 		result, err := ValidateSkill(fixturePath(t, tc.name), Options{CheckRefsExist: true})
@@ -55,6 +60,37 @@ func TestFrontmatterErrors(t *testing.T) {
 	}
 }
 
+func TestDuplicateKeys(t *testing.T) {
+	result, err := ValidateSkill(fixturePath(t, "invalid-frontmatter-duplicate-key"), Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Finding{
+		{Level: LevelError, Code: codeFrontmatterDuplicateKey, Message: "Frontmatter key 'metadata.version' is already defined on line 5.", File: "SKILL.md", Line: 6, Column: 3, EndLine: 6, EndColumn: 10},
+		{Level: LevelError, Code: codeFrontmatterDuplicateKey, Message: "Frontmatter key 'name' is already defined on line 2.", File: "SKILL.md", Line: 7, Column: 1, EndLine: 7, EndColumn: 5},
+	}
+	if !reflect.DeepEqual(result.Errors, want) {
+		t.Fatalf("expected %#v, got %#v", want, result.Errors)
+	}
+}
+
+func TestDuplicateKeysKeepValidating(t *testing.T) {
+	dir := writeSkill(t, "my-skill", "---\nname: Bad_Name\ndescription: First.\ndescription: Second.\n"+
+		"x-owner: docs # sklint-disable UNKNOWN_TOP_LEVEL_KEY\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codeFrontmatterDuplicateKey)
+	assertFinding(t, result, LevelError, codeNameInvalidChars)
+	if result.Description != "First." {
+		t.Fatalf("expected the first description, got %q", result.Description)
+	}
+	if len(result.Suppressed) != 1 || result.Suppressed[0].Code != codeUnknownTopLevelKey {
+		t.Fatalf("expected the unknown key to be suppressed, got %#v", result.Suppressed)
+	}
+}
+
 func TestNameViolations(t *testing.T) {
 	cases := []struct {
 		name string
@@ -168,6 +204,21 @@ func TestStrictMode(t *testing.T) {
 	}
 }
 
+func TestValidateContentUsesGivenContent(t *testing.T) {
+	dir := fixturePath(t, "invalid-missing-skillmd")
+	content := []byte("---\nname: Invalid_Name\ndescription: Checks an unsaved buffer.\n---\n\nBody\n")
+	result, err := ValidateContent(dir, content, Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codeNameInvalidChars)
+	for _, finding := range result.Errors {
+		if finding.Code == codeSkillMDMissing {
+			t.Fatalf("SKILL.md on disk should not be consulted")
+		}
+	}
+}
+
 func assertFinding(t *testing.T, result Result, level FindingLevel, code string) {
 	t.Helper()
 	for _, finding := range result.Errors {
@@ -182,3 +233,229 @@ func assertFinding(t *testing.T, result Result, level FindingLevel, code string)
 	}
 	t.Fatalf("expected finding %s/%s not found", level, code)
 }
+
+func TestFindingCodesAreDocumented(t *testing.T) {
+	documented := make(map[string]bool)
+	for _, info := range Codes() {
+		if documented[info.Code] {
+			t.Fatalf("code %s listed twice", info.Code)
+		}
+		documented[info.Code] = true
+	}
+	results, err := ValidateSkills([]string{filepath.Dir(fixturePath(t, "valid-minimal"))}, Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, result := range results {
+		for _, finding := range append(result.Errors, result.Warnings...) {
+			if !documented[finding.Code] {
+				t.Fatalf("code %s is not listed in Codes()", finding.Code)
+			}
+		}
+	}
+}
+
+func TestSeverityOverrides(t *testing.T) {
+	result, err := ValidateSkill(fixturePath(t, "reference-warnings"), Options{
+		CheckRefsExist: true,
+		NoWarn:         true,
+		Severity: map[string]FindingLevel{
+			codeRefTooDeep:     LevelOff,
+			codeRefMissingFile: LevelError,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Valid {
+		t.Fatalf("expected promoted warning to invalidate the result")
+	}
+	assertFinding(t, result, LevelError, codeRefMissingFile)
+	if len(result.Warnings) != 0 {
+		t.Fatalf("expected remaining warnings to be suppressed by NoWarn, got %#v", result.Warnings)
+	}
+	for _, finding := range result.Errors {
+		if finding.Code == codeRefTooDeep {
+			t.Fatalf("expected %s to be turned off", codeRefTooDeep)
+		}
+	}
+}
+
+func TestDisabledCodes(t *testing.T) {
+	result, err := ValidateSkill(fixturePath(t, "valid-minimal"), Options{
+		CheckRefsExist: true,
+		NoWarn:         true,
+		Severity: map[string]FindingLevel{
+			codeNameMismatchDirectory: LevelOff,
+			codeRefMissingFile:        LevelError,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	disabled := make(map[string]bool)
+	for _, code := range result.Disabled {
+		disabled[code] = true
+	}
+	for _, code := range []string{codeNameMismatchDirectory, codeYAMLAnchor, codeRefTooDeep} {
+		if !disabled[code] {
+			t.Fatalf("expected %s to be disabled, got %v", code, result.Disabled)
+		}
+	}
+	for _, code := range []string{codeNameMissing, codeRefMissingFile} {
+		if disabled[code] {
+			t.Fatalf("expected %s to be enabled, got %v", code, result.Disabled)
+		}
+	}
+	if codes := DisabledCodes(Options{Portability: true}); len(codes) != 0 {
+		t.Fatalf("expected no disabled codes, got %v", codes)
+	}
+}
+
+func TestInlineSuppressions(t *testing.T) {
+	result, err := ValidateSkill(fixturePath(t, "suppressions"), Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	suppressed := make(map[string]int)
+	for _, finding := range result.Suppressed {
+		if !finding.Suppressed {
+			t.Fatalf("expected suppressed marker on %#v", finding)
+		}
+		suppressed[finding.Code] = finding.Line
+	}
+	if suppressed[codeUnknownTopLevelKey] != 5 {
+		t.Fatalf("expected unknown key on line 5 to be suppressed, got %#v", result.Suppressed)
+	}
+	if suppressed[codeRefMissingFile] != 8 {
+		t.Fatalf("expected missing reference on line 8 to be suppressed, got %#v", result.Suppressed)
+	}
+
+	var missing, unused []Finding
+	for _, finding := range result.Warnings {
+		switch finding.Code {
+		case codeRefMissingFile:
+			missing = append(missing, finding)
+		case codeSuppressionUnused:
+			unused = append(unused, finding)
+		}
+	}
+	if len(missing) != 1 || missing[0].Line != 9 {
+		t.Fatalf("expected only the unsuppressed reference to be reported, got %#v", missing)
+	}
+	if len(unused) != 1 || unused[0].Line != 10 {
+		t.Fatalf("expected unused suppression on line 10, got %#v", unused)
+	}
+}
+
+func TestFrontmatterSuppressionNeedsCodes(t *testing.T) {
+	dir := writeSkill(t, "my-skill", "---\nname: Bad_Name\ndescription: Bare.\n# sklint-disable\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Valid || len(result.Suppressed) != 0 {
+		t.Fatalf("expected a bare frontmatter suppression to hide nothing, got %#v", result.Suppressed)
+	}
+	assertFinding(t, result, LevelError, codeNameInvalidChars)
+	assertFinding(t, result, LevelError, codeNameMismatchDirectory)
+	assertFinding(t, result, LevelWarning, codeSuppressionUnused)
+}
+
+func TestFrontmatterSuppressionIgnoresQuotedValues(t *testing.T) {
+	dir := writeSkill(t, "my-skill", "---\nname: my-skill\n"+
+		"description: \"use # sklint-disable UNKNOWN_TOP_LEVEL_KEY here\" # sklint-disable X_UNUSED\n"+
+		"x-owner: docs\n---\nBody\n")
+	result, err := ValidateSkill(dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelWarning, codeUnknownTopLevelKey)
+	var unused []Finding
+	for _, finding := range result.Warnings {
+		if finding.Code == codeSuppressionUnused {
+			unused = append(unused, finding)
+		}
+	}
+	if len(unused) != 1 || unused[0].Message != "Suppression for X_UNUSED does not match any finding." || unused[0].Column != 64 {
+		t.Fatalf("expected only the trailing comment to be a suppression, got %#v", unused)
+	}
+}
+
+func TestUnusedSuppressionSeverity(t *testing.T) {
+	result, err := ValidateSkill(fixturePath(t, "suppressions"), Options{
+		CheckRefsExist: true,
+		Severity:       map[string]FindingLevel{codeSuppressionUnused: LevelOff},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, finding := range append(result.Errors, result.Warnings...) {
+		if finding.Code == codeSuppressionUnused {
+			t.Fatalf("expected %s to be turned off, got %#v", codeSuppressionUnused, finding)
+		}
+	}
+
+	result, err = ValidateSkill(fixturePath(t, "suppressions"), Options{
+		CheckRefsExist: true,
+		Severity:       map[string]FindingLevel{codeSuppressionUnused: LevelError},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFinding(t, result, LevelError, codeSuppressionUnused)
+	if result.Valid {
+		t.Fatal("expected promoted unused suppression to invalidate the result")
+	}
+}
+
+func TestFindingRanges(t *testing.T) {
+	cases := []struct {
+		fixture string
+		code    string
+		want    [4]int
+	}{
+		{"invalid-name-mismatch", codeNameMismatchDirectory, [4]int{2, 7, 2, 17}},
+		{"invalid-frontmatter-invalid-yaml", codeFrontmatterInvalidYAML, [4]int{3, 1, 3, 25}},
+		{"reference-warnings", codeRefTooDeep, [4]int{6, 12, 6, 37}},
+		{"suppressions", codeSuppressionUnused, [4]int{10, 1, 10, 47}},
+		{"strict-warning-only", codeSkillMDMissingBody, [4]int{4, 1, 4, 4}},
+	}
+	for _, tc := range cases {
+		result, err := ValidateSkill(fixturePath(t, tc.fixture), Options{CheckRefsExist: true})
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", tc.fixture, err)
+		}
+		found := false
+		for _, finding := range append(result.Errors, result.Warnings...) {
+			if finding.Code != tc.code {
+				continue
+			}
+			found = true
+			got := [4]int{finding.Line, finding.Column, finding.EndLine, finding.EndColumn}
+			if got != tc.want {
+				t.Fatalf("%s: expected range %v for %s, got %v", tc.fixture, tc.want, tc.code, got)
+			}
+		}
+		if !found {
+			t.Fatalf("%s: expected finding %s", tc.fixture, tc.code)
+		}
+	}
+}
+
+func TestSkillMDFindingsHaveRanges(t *testing.T) {
+	results, err := ValidateSkills([]string{filepath.Dir(fixturePath(t, "valid-minimal"))}, Options{CheckRefsExist: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, result := range results {
+		for _, finding := range append(result.Errors, result.Warnings...) {
+			if finding.File != "SKILL.md" || finding.Code == codeSkillMDMissing {
+				continue
+			}
+			if finding.Line == 0 || finding.Column == 0 || finding.EndLine == 0 || finding.EndColumn == 0 {
+				t.Fatalf("%s: finding %s has no range: %#v", result.Path, finding.Code, finding)
+			}
+		}
+	}
+}